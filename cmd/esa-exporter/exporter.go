@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/iwata/go-esa/esa"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "esa"
+
+// minIntervalPerTeam is the minimum interval between two scrapes of a team.
+// esa limits each user to 75 requests per 15 minutes; requesting the stats
+// of a team every 2 minutes uses at most 10% of it, leaving the rest to the
+// other tools sharing the access token.
+//
+// ref. https://docs.esa.io/posts/102#2-3-0
+const minIntervalPerTeam = 2 * time.Minute
+
+// safeInterval returns the scrape interval to use for the given number of
+// teams, raising interval if it would consume too much of the rate limit.
+func safeInterval(interval time.Duration, teams int) time.Duration {
+	if min := minIntervalPerTeam * time.Duration(teams); interval < min {
+		return min
+	}
+	return interval
+}
+
+// teamStat is a field of esa.TeamStats exported as a gauge.
+type teamStat struct {
+	name  string
+	help  string
+	value func(*esa.TeamStats) int
+}
+
+var teamStats = []teamStat{
+	{"members", "Number of members of the team.", func(s *esa.TeamStats) int { return s.Members }},
+	{"posts", "Number of posts of the team.", func(s *esa.TeamStats) int { return s.Posts }},
+	{"posts_wip", "Number of WIP posts of the team.", func(s *esa.TeamStats) int { return s.PostsWIP }},
+	{"posts_shipped", "Number of shipped posts of the team.", func(s *esa.TeamStats) int { return s.PostsShipped }},
+	{"comments", "Number of comments of the team.", func(s *esa.TeamStats) int { return s.Comments }},
+	{"stars", "Number of stars of the team.", func(s *esa.TeamStats) int { return s.Stars }},
+	{"daily_active_users", "Number of members active in the last day.", func(s *esa.TeamStats) int { return s.DailyActiveUsers }},
+	{"weekly_active_users", "Number of members active in the last week.", func(s *esa.TeamStats) int { return s.WeeklyActiveUsers }},
+	{"monthly_active_users", "Number of members active in the last month.", func(s *esa.TeamStats) int { return s.MonthlyActiveUsers }},
+}
+
+// exporter periodically fetches the statistics of teams and keeps them in
+// Prometheus gauges.
+type exporter struct {
+	client *esa.Client
+	teams  []string
+	logger *slog.Logger
+
+	stats         []*prometheus.GaugeVec // in the order of teamStats
+	rateLimit     prometheus.Gauge
+	rateRemaining prometheus.Gauge
+	rateReset     prometheus.Gauge
+	scrapeErrors  *prometheus.CounterVec
+	lastScrape    *prometheus.GaugeVec
+}
+
+func newExporter(client *esa.Client, teams []string, logger *slog.Logger) *exporter {
+	e := &exporter{
+		client: client,
+		teams:  teams,
+		logger: logger,
+		rateLimit: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: "rate_limit", Name: "limit",
+			Help: "Number of requests allowed in the current rate limit window.",
+		}),
+		rateRemaining: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: "rate_limit", Name: "remaining",
+			Help: "Number of requests remaining in the current rate limit window.",
+		}),
+		rateReset: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: "rate_limit", Name: "reset_timestamp_seconds",
+			Help: "Time at which the current rate limit window is reset.",
+		}),
+		scrapeErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Subsystem: "exporter", Name: "scrape_errors_total",
+			Help: "Number of errors fetching the statistics of a team.",
+		}, []string{"team"}),
+		lastScrape: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: "exporter", Name: "last_scrape_success_timestamp_seconds",
+			Help: "Time at which the statistics of a team were last fetched.",
+		}, []string{"team"}),
+	}
+	for _, s := range teamStats {
+		e.stats = append(e.stats, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: "team", Name: s.name,
+			Help: s.help,
+		}, []string{"team"}))
+	}
+	return e
+}
+
+// register registers the metrics of e with r.
+func (e *exporter) register(r prometheus.Registerer) error {
+	cs := []prometheus.Collector{e.rateLimit, e.rateRemaining, e.rateReset, e.scrapeErrors, e.lastScrape}
+	for _, g := range e.stats {
+		cs = append(cs, g)
+	}
+	for _, c := range cs {
+		if err := r.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scrape fetches the statistics of every team once.
+func (e *exporter) scrape(ctx context.Context) {
+	for _, team := range e.teams {
+		stats, _, err := e.client.Teams.GetStats(ctx, team)
+		if rate := e.client.Rate(); rate.Limit > 0 {
+			e.setRate(rate)
+		}
+		if err != nil {
+			e.scrapeErrors.WithLabelValues(team).Inc()
+			e.logger.Error("failed to fetch team stats", slog.String("team", team), slog.Any("error", err))
+			continue
+		}
+		for i, s := range teamStats {
+			e.stats[i].WithLabelValues(team).Set(float64(s.value(stats)))
+		}
+		e.lastScrape.WithLabelValues(team).SetToCurrentTime()
+	}
+}
+
+func (e *exporter) setRate(r esa.Rate) {
+	e.rateLimit.Set(float64(r.Limit))
+	e.rateRemaining.Set(float64(r.Remaining))
+	e.rateReset.Set(float64(r.Reset.Unix()))
+}
+
+// run scrapes the teams every interval until ctx is done.
+func (e *exporter) run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		e.scrape(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
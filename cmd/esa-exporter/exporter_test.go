@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iwata/go-esa/esa"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSafeInterval(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		teams    int
+		want     time.Duration
+	}{
+		{10 * time.Minute, 1, 10 * time.Minute},
+		{time.Minute, 1, 2 * time.Minute},
+		{10 * time.Minute, 6, 12 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := safeInterval(tt.interval, tt.teams); got != tt.want {
+			t.Errorf("safeInterval(%v, %d) = %v, want %v", tt.interval, tt.teams, got, tt.want)
+		}
+	}
+}
+
+func TestExporter_scrape(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/v1/teams/docs/stats", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "75")
+		w.Header().Set("X-RateLimit-Remaining", "73")
+		w.Header().Set("X-RateLimit-Reset", "1504574600")
+		fmt.Fprint(w, `{
+  "members": 20,
+  "posts": 1959,
+  "posts_wip": 59,
+  "posts_shipped": 1900,
+  "comments": 26,
+  "stars": 1710,
+  "daily_active_users": 8,
+  "weekly_active_users": 14,
+  "monthly_active_users": 15
+}`)
+	})
+	mux.HandleFunc("/v1/teams/hoge/stats", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	u, _ := url.Parse(server.URL + "/")
+	client := esa.NewClient(nil, esa.WithBaseURL(u))
+	e := newExporter(client, []string{"docs", "hoge"}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	reg := prometheus.NewRegistry()
+	if err := e.register(reg); err != nil {
+		t.Fatalf("register returned error: %v", err)
+	}
+
+	e.scrape(context.Background())
+
+	expected := `
+# HELP esa_team_members Number of members of the team.
+# TYPE esa_team_members gauge
+esa_team_members{team="docs"} 20
+# HELP esa_team_posts_wip Number of WIP posts of the team.
+# TYPE esa_team_posts_wip gauge
+esa_team_posts_wip{team="docs"} 59
+# HELP esa_team_monthly_active_users Number of members active in the last month.
+# TYPE esa_team_monthly_active_users gauge
+esa_team_monthly_active_users{team="docs"} 15
+# HELP esa_rate_limit_remaining Number of requests remaining in the current rate limit window.
+# TYPE esa_rate_limit_remaining gauge
+esa_rate_limit_remaining 73
+# HELP esa_rate_limit_reset_timestamp_seconds Time at which the current rate limit window is reset.
+# TYPE esa_rate_limit_reset_timestamp_seconds gauge
+esa_rate_limit_reset_timestamp_seconds 1.5045746e+09
+# HELP esa_exporter_scrape_errors_total Number of errors fetching the statistics of a team.
+# TYPE esa_exporter_scrape_errors_total counter
+esa_exporter_scrape_errors_total{team="hoge"} 1
+`
+	err := testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"esa_team_members", "esa_team_posts_wip", "esa_team_monthly_active_users",
+		"esa_rate_limit_remaining", "esa_rate_limit_reset_timestamp_seconds",
+		"esa_exporter_scrape_errors_total")
+	if err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(e.stats[0]); n != 1 {
+		t.Errorf("Exported members of %d teams, want only the one fetched", n)
+	}
+}
@@ -0,0 +1,81 @@
+// Command esa-exporter exposes the statistics of esa teams and the rate
+// limit of the access token as Prometheus metrics.
+//
+//	ESA_ACCESS_TOKEN=... esa-exporter -teams docs,hoge -listen :9723
+//
+// The statistics are fetched every -interval, which is raised if needed to
+// keep the exporter well under the rate limit of esa.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/iwata/go-esa/esa"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func main() {
+	var (
+		teams    = flag.String("teams", "", "comma-separated names of the teams to export")
+		listen   = flag.String("listen", ":9723", "address to serve the metrics on")
+		path     = flag.String("path", "/metrics", "path to serve the metrics on")
+		interval = flag.Duration("interval", 10*time.Minute, "interval between scrapes of esa")
+	)
+	flag.Parse()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	if err := run(logger, *teams, *listen, *path, *interval); err != nil {
+		logger.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(logger *slog.Logger, teams, listen, path string, interval time.Duration) error {
+	names := strings.FieldsFunc(teams, func(r rune) bool { return r == ',' || r == ' ' })
+	if len(names) == 0 {
+		return fmt.Errorf("no team given with -teams")
+	}
+	if os.Getenv(esa.EnvAccessToken) == "" {
+		return fmt.Errorf("%s is not set", esa.EnvAccessToken)
+	}
+	if safe := safeInterval(interval, len(names)); safe != interval {
+		logger.Warn("raising the interval to stay under the rate limit",
+			slog.Duration("interval", interval), slog.Duration("safe_interval", safe))
+		interval = safe
+	}
+
+	client := esa.NewClient(nil, esa.WithToken(""), esa.WithUserAgent("esa-exporter"))
+	e := newExporter(client, names, logger)
+	reg := prometheus.NewRegistry()
+	if err := e.register(reg); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go e.run(ctx, interval)
+
+	mux := http.NewServeMux()
+	mux.Handle(path, promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	srv := &http.Server{Addr: listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	logger.Info("serving metrics", slog.String("addr", listen), slog.String("path", path), slog.Duration("interval", interval))
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package esa
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Cache stores API responses to make conditional requests with. esa answers
+// them with 304 Not Modified when the resource is unchanged, in which case the
+// stored response is returned by Client.Do instead.
+//
+// The responses are stored under the URL of the request, so a Cache must not
+// be shared by clients authenticated as different users.
+type Cache interface {
+	// Get returns the response stored under key, if any.
+	Get(key string) ([]byte, bool)
+
+	// Set stores a response under key.
+	Set(key string, data []byte)
+
+	// Delete removes the response stored under key.
+	Delete(key string)
+}
+
+// MemoryCache is a Cache storing responses in memory.
+// It is safe for concurrent use.
+type MemoryCache struct {
+	mu    sync.Mutex
+	items map[string][]byte
+}
+
+// NewMemoryCache returns an empty MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{items: make(map[string][]byte)}
+}
+
+// Get implements Cache.
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, ok := c.items[key]
+	return data, ok
+}
+
+// Set implements Cache.
+func (c *MemoryCache) Set(key string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[key] = data
+}
+
+// Delete implements Cache.
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.items, key)
+}
+
+// DiskCache is a Cache storing responses as files in a directory, so that
+// they survive restarts of the program. Failures to read or write the files
+// are treated as cache misses.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache returns a DiskCache storing responses in dir, which is
+// created if it does not exist.
+func NewDiskCache(dir string) *DiskCache {
+	return &DiskCache{dir: dir}
+}
+
+// Get implements Cache.
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	data, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Set implements Cache.
+func (c *DiskCache) Set(key string, data []byte) {
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return
+	}
+	// Write to a temporary file first not to leave a partial response.
+	f, err := ioutil.TempFile(c.dir, ".tmp-")
+	if err != nil {
+		return
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), c.path(key))
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+}
+
+// Delete implements Cache.
+func (c *DiskCache) Delete(key string) {
+	_ = os.Remove(c.path(key))
+}
+
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// cacheRoundTrip wraps next to make conditional requests with the responses
+// stored in c.cache.
+func (c *Client) cacheRoundTrip(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*Response, error) {
+		key := req.URL.String()
+		if req.Method != "GET" || req.Header.Get("Range") != "" {
+			resp, err := next(req)
+			if err == nil && req.Method != "HEAD" {
+				// The resource has probably been modified.
+				c.cache.Delete(key)
+			}
+			return resp, err
+		}
+
+		cached := c.cachedResponse(key, req)
+		if cached != nil {
+			req = req.Clone(req.Context())
+			if etag := cached.Header.Get("ETag"); etag != "" {
+				req.Header.Set("If-None-Match", etag)
+			}
+			if lm := cached.Header.Get("Last-Modified"); lm != "" {
+				req.Header.Set("If-Modified-Since", lm)
+			}
+		}
+
+		resp, err := next(req)
+		if resp == nil || resp.Response == nil {
+			return resp, err
+		}
+		if resp.StatusCode == http.StatusNotModified && cached != nil {
+			// CheckResponse reports 304 as an error, but here it answers
+			// the conditional request made with the cached response.
+			closeBody(resp)
+			return &Response{
+				Response:  cached,
+				Rate:      resp.Rate,
+				Attempts:  resp.Attempts,
+				FromCache: true,
+			}, nil
+		}
+		if err != nil {
+			return resp, err
+		}
+		if resp.StatusCode == http.StatusOK &&
+			(resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != "") {
+			// DumpResponse replaces the body with an equivalent one.
+			if data, err := httputil.DumpResponse(resp.Response, true); err == nil {
+				c.cache.Set(key, data)
+			}
+		}
+		return resp, nil
+	}
+}
+
+// cachedResponse returns the response stored under key, or nil.
+func (c *Client) cachedResponse(key string, req *http.Request) *http.Response {
+	data, ok := c.cache.Get(key)
+	if !ok {
+		return nil
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), req)
+	if err != nil {
+		c.cache.Delete(key)
+		return nil
+	}
+	return resp
+}
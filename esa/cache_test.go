@@ -0,0 +1,175 @@
+package esa
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClient_cache_etag(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var n int
+	mux.HandleFunc("/v1/teams/hoge", func(w http.ResponseWriter, r *http.Request) {
+		n++
+		w.Header().Set(headerRateLimit, "75")
+		w.Header().Set(headerRateRemaining, fmt.Sprint(75-n))
+		w.Header().Set(headerRateReset, "1504574600")
+		if n > 1 {
+			if got, want := r.Header.Get("If-None-Match"), `"abc"`; got != want {
+				t.Errorf("If-None-Match is %v, want %v", got, want)
+			}
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"abc"`)
+		fmt.Fprint(w, `{"name": "hoge", "privacy": "open"}`)
+	})
+
+	client.cache = NewMemoryCache()
+	want := &Team{Name: "hoge", Privacy: "open"}
+
+	team, resp, err := client.Teams.Get(context.Background(), "hoge")
+	if err != nil {
+		t.Fatalf("Teams.Get returned error: %v", err)
+	}
+	if !reflect.DeepEqual(team, want) {
+		t.Errorf("Teams.Get returned %+v, want %+v", team, want)
+	}
+	if resp.FromCache {
+		t.Error("First response is served from the cache")
+	}
+
+	req, _ := client.NewRequest("GET", "teams/hoge", nil)
+	team = &Team{}
+	resp, err = client.Do(context.Background(), req, team)
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if !reflect.DeepEqual(team, want) {
+		t.Errorf("Do returned %+v from the cache, want %+v", team, want)
+	}
+	if !resp.FromCache {
+		t.Error("Response is not served from the cache")
+	}
+	if got, want := resp.StatusCode, http.StatusOK; got != want {
+		t.Errorf("Cached response status is %v, want %v", got, want)
+	}
+	if got, want := resp.Rate.Remaining, 73; got != want {
+		t.Errorf("Rate.Remaining is %v, want the one of the 304 response %v", got, want)
+	}
+	if got := req.Header.Get("If-None-Match"); got != "" {
+		t.Errorf("Cache modified the original request: If-None-Match is %v", got)
+	}
+}
+
+func TestClient_cache_lastModified(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const lastModified = "Tue, 05 Sep 2017 01:00:00 GMT"
+	var n int
+	mux.HandleFunc("/v1/teams/hoge/stats", func(w http.ResponseWriter, r *http.Request) {
+		n++
+		if n > 1 {
+			if got := r.Header.Get("If-Modified-Since"); got != lastModified {
+				t.Errorf("If-Modified-Since is %v, want %v", got, lastModified)
+			}
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified)
+		fmt.Fprint(w, `{"members": 20}`)
+	})
+
+	client.cache = NewDiskCache(t.TempDir())
+	for i := 0; i < 2; i++ {
+		stats, resp, err := client.Teams.GetStats(context.Background(), "hoge")
+		if err != nil {
+			t.Fatalf("Teams.GetStats returned error: %v", err)
+		}
+		if stats.Members != 20 {
+			t.Errorf("Teams.GetStats returned %+v", stats)
+		}
+		if got, want := resp.FromCache, i > 0; got != want {
+			t.Errorf("%d. FromCache is %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestClient_cache_notModifiedWithoutEntry(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	})
+
+	client.cache = NewMemoryCache()
+	_, resp, err := client.Teams.Get(context.Background(), "hoge")
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotModified {
+		t.Errorf("Teams.Get returned Response %v, want the 304 response", resp)
+	} else if resp.FromCache {
+		t.Error("Response without a cache entry is served from the cache")
+	}
+}
+
+func TestClient_cache_invalidate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.Header.Get("If-None-Match") != "" {
+			t.Error("Sent a conditional request after the post was updated")
+		}
+		w.Header().Set("ETag", `"abc"`)
+		fmt.Fprint(w, `{"number": 1}`)
+	})
+
+	client.cache = NewMemoryCache()
+	ctx := context.Background()
+	if _, _, err := client.Posts.Get(ctx, "hoge", 1); err != nil {
+		t.Fatalf("Posts.Get returned error: %v", err)
+	}
+	if _, _, err := client.Posts.Update(ctx, "hoge", 1, &PostRequest{Name: "hi!"}); err != nil {
+		t.Fatalf("Posts.Update returned error: %v", err)
+	}
+	if _, _, err := client.Posts.Get(ctx, "hoge", 1); err != nil {
+		t.Fatalf("Posts.Get returned error: %v", err)
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	c := NewDiskCache(t.TempDir() + "/cache")
+
+	if _, ok := c.Get("key"); ok {
+		t.Error("Get returned a value of an empty cache")
+	}
+	c.Set("key", []byte("value"))
+	if got, ok := c.Get("key"); !ok || string(got) != "value" {
+		t.Errorf("Get returned %q, %v, want %q", got, ok, "value")
+	}
+	c.Delete("key")
+	if _, ok := c.Get("key"); ok {
+		t.Error("Get returned a deleted value")
+	}
+}
+
+func TestCheckResponse_notModified(t *testing.T) {
+	res := &http.Response{
+		Request:    &http.Request{},
+		StatusCode: http.StatusNotModified,
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}
+	if err := CheckResponse(res); err == nil {
+		t.Error("Expected error to be returned.")
+	}
+}
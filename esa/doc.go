@@ -8,24 +8,20 @@ Package esa is a client library for esa.io API v1.
 		"context"
 		"fmt"
 		"log"
+		"os"
 
 		"github.com/iwata/go-esa/esa"
-		"golang.org/x/oauth2"
 	)
 
 
 	func main() {
-		ts := oauth2.StaticTokenSource(
-			&oauth2.Token{AccessToken: os.Getenv("ESA_TOKEN")},
-		)
-
+		// Read the access token from ESA_ACCESS_TOKEN
+		client := esa.NewClient(nil, esa.WithToken(""))
 		ctx := context.Background()
-		tc := oauth2.NewClient(ctx, ts)
-		client := esa.NewClient(tc)
 
 		// Fetch joining teams
 		// ref. https://docs.esa.io/posts/102#4-1-0
-		teamList, _, err := client.Teams.List(ctx)
+		teamList, _, err := client.Teams.List(ctx, nil)
 		if err != nil {
 			log.Panic(err)
 		}
@@ -9,8 +9,10 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
@@ -18,8 +20,9 @@ import (
 )
 
 const (
-	baseURL    = "https://api.esa.io/"
-	apiVersion = "v1"
+	baseURL          = "https://api.esa.io/"
+	apiVersion       = "v1"
+	defaultUserAgent = "go-esa"
 
 	// RateLimit Headers
 	// ref. https://docs.esa.io/posts/102#2-3-0
@@ -33,14 +36,45 @@ type Client struct {
 	client  *http.Client
 	BaseURL *url.URL
 
-	rateMu    sync.Mutex
-	rateLimit Rate // Rate limit for the client as determined by the most recent API calls.
+	// User agent used when communicating with the esa API.
+	UserAgent string
+
+	// RateLimitPolicy determines what Do does when the rate limit is exhausted.
+	RateLimitPolicy RateLimitPolicy
+
+	// RetryPolicy determines whether Do retries failed requests.
+	// If nil, every request is sent only once.
+	RetryPolicy *RetryPolicy
+
+	rateMu       sync.Mutex
+	rateLimit    Rate       // Rate limit for the client as determined by the most recent API calls.
+	throttleNext time.Time  // The earliest time the next request may be sent under RateLimitThrottle.
+	onRateChange func(Rate) // Called when the rate limit changes, may be nil.
+	scheduler    *scheduler // Scheduler of concurrent requests, nil to send them as they come.
+
+	sleep func(ctx context.Context, d time.Duration) error
+
+	logger      *slog.Logger // Logger for API calls, retries and rate limit waits, nil to disable logging.
+	middleware  []Middleware // Middleware wrapping Do, the outermost first.
+	cache       Cache        // Cache for conditional requests, nil to disable caching.
+	storage     *http.Client // HTTP client for the storage of attachments, nil to use defaultStorageClient.
+	defaultTeam string       // Team name used when an empty team name is given.
 
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 	// Services used for talking to different parts of the esa API.
 
 	Teams       *TeamsService
 	Invitations *InvitationsService
+	Posts       *PostsService
+	Comments    *CommentsService
+	Members     *MembersService
+	User        *UserService
+	Stars       *StarsService
+	Watchers    *WatchersService
+	Categories  *CategoriesService
+	Tags        *TagsService
+	Emojis      *EmojisService
+	Attachments *AttachmentsService
 
 	err error
 }
@@ -49,6 +83,14 @@ type Client struct {
 type Response struct {
 	*http.Response
 	Rate
+
+	// Attempts is the number of attempts made to get this response.
+	Attempts int
+
+	// FromCache reports whether the response was served from the Cache of
+	// the client after esa answered 304 Not Modified. Rate is then the one
+	// of the 304 response.
+	FromCache bool
 }
 
 // newResponse creates a new Response for the provided http.Response.
@@ -107,26 +149,142 @@ func (r Rate) String() string {
 	return Stringify(r)
 }
 
+// Err returns the error which occurred while parsing the rate limit headers,
+// if any.
+func (r Rate) Err() error {
+	return r.err
+}
+
 type service struct {
 	client *Client
 }
 
+// ListOptions specifies the optional parameters to various List methods that
+// support pagination.
+type ListOptions struct {
+	// For paginated result sets, page of results to retrieve.
+	Page int `url:"page,omitempty"`
+
+	// For paginated result sets, the number of results to include per page.
+	PerPage int `url:"per_page,omitempty"`
+}
+
+// addOptions adds the parameters in opts as URL query parameters to s. opts
+// must be a struct whose fields may contain "url" tags.
+func addOptions(s string, opts interface{}) (string, error) {
+	v := reflect.ValueOf(opts)
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return s, nil
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return s, err
+	}
+
+	qs := u.Query()
+	if err := encodeOptions(qs, reflect.Indirect(v)); err != nil {
+		return s, err
+	}
+
+	u.RawQuery = qs.Encode()
+	return u.String(), nil
+}
+
+// encodeOptions sets the fields of the struct v tagged with "url" to qs.
+// Embedded structs are encoded as if their fields were declared on v.
+func encodeOptions(qs url.Values, v reflect.Value) error {
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("esa: options must be a struct, got %v", v.Kind())
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := v.Field(i)
+		if sf.Anonymous && fv.Kind() == reflect.Struct {
+			if err := encodeOptions(qs, fv); err != nil {
+				return err
+			}
+			continue
+		}
+
+		tag := sf.Tag.Get("url")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		name, opt := tag, ""
+		if i := strings.Index(tag, ","); i >= 0 {
+			name, opt = tag[:i], tag[i+1:]
+		}
+		if opt == "omitempty" && isZeroValue(fv) {
+			continue
+		}
+
+		switch fv.Kind() {
+		case reflect.String:
+			qs.Set(name, fv.String())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			qs.Set(name, strconv.FormatInt(fv.Int(), 10))
+		case reflect.Bool:
+			qs.Set(name, strconv.FormatBool(fv.Bool()))
+		default:
+			return fmt.Errorf("esa: unsupported option type %v of %s", fv.Kind(), sf.Name)
+		}
+	}
+	return nil
+}
+
+func isZeroValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String:
+		return v.Len() == 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	}
+	return false
+}
+
 // NewClient returns a new esa API client. If a nil httpClient is
 // provided, http.DefaultClient will be used. To use API methods which require
 // authentication, provide an http.Client that will perform the authentication
-// for you (such as that provided by the golang.org/x/oauth2 library).
-func NewClient(httpClient *http.Client) *Client {
+// for you (such as that provided by the golang.org/x/oauth2 library), or use
+// WithToken option.
+func NewClient(httpClient *http.Client, opts ...ClientOption) *Client {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
 	baseURL, err := url.Parse(baseURL)
-	c := &Client{client: httpClient, BaseURL: baseURL, err: err}
+	c := &Client{client: httpClient, BaseURL: baseURL, UserAgent: defaultUserAgent, sleep: sleepContext, err: err}
 	c.common.client = c
 	c.Teams = (*TeamsService)(&c.common)
 	c.Invitations = (*InvitationsService)(&c.common)
+	c.Posts = (*PostsService)(&c.common)
+	c.Comments = (*CommentsService)(&c.common)
+	c.Members = (*MembersService)(&c.common)
+	c.User = (*UserService)(&c.common)
+	c.Stars = (*StarsService)(&c.common)
+	c.Watchers = (*WatchersService)(&c.common)
+	c.Categories = (*CategoriesService)(&c.common)
+	c.Tags = (*TagsService)(&c.common)
+	c.Emojis = (*EmojisService)(&c.common)
+	c.Attachments = (*AttachmentsService)(&c.common)
+	for _, opt := range opts {
+		opt(c)
+	}
 	return c
 }
 
+// teamName returns team, or the default team of the client if team is empty.
+func (c *Client) teamName(team string) string {
+	if team == "" {
+		return c.defaultTeam
+	}
+	return team
+}
+
 // NewRequest creates an API request. A relative URL can be provided in urlStr,
 // in which case it is resolved relative to the BaseURL of the Client.
 // Relative URLs should always be specified without a preceding slash. If
@@ -163,6 +321,9 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
 	return req, nil
 }
 
@@ -174,19 +335,110 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 //
 // The provided ctx must be non-nil. If it is canceled or times out,
 // ctx.Err() will be returned.
-// nolint: gocyclo
+//
+// Unless c.RateLimitPolicy is RateLimitFailFast, Do waits for the rate limit
+// to be reset instead of returning *RateLimitError. Requests failing
+// transiently are retried according to c.RetryPolicy. The whole exchange is
+// wrapped by the middleware registered with Use.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
-	// If we've hit rate limit, don't make further requests before Reset time.
-	if err := c.checkRateLimitBeforeDo(req); err != nil {
-		return &Response{
-			Response: err.Response,
-			Rate:     err.Rate,
-		}, err
+	resp, err := c.handler()(req.WithContext(ctx))
+	// Drain and close the body to let the Transport reuse the connection.
+	// Errors are ignored not to race with concurrent calls of Do.
+	defer closeBody(resp)
+	if err != nil {
+		// even though there was an error, we still return the response
+		// in case the caller wants to inspect it further
+		return resp, err
+	}
+
+	if v != nil {
+		if w, ok := v.(io.Writer); ok {
+			_, err = io.Copy(w, resp.Body)
+		} else {
+			err = json.NewDecoder(resp.Body).Decode(v)
+			if err == io.EOF {
+				err = nil // ignore EOF errors caused by empty response body
+			}
+		}
+	}
+
+	return resp, err
+}
+
+// roundTrip sends an API request, retrying it according to c.RetryPolicy.
+// The body of the returned response is left for the caller to read and close.
+func (c *Client) roundTrip(req *http.Request) (*Response, error) {
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		resp, err := c.doRateLimited(ctx, req)
+		if resp != nil {
+			resp.Attempts = attempt
+		}
+		if !c.RetryPolicy.shouldRetry(ctx, req, resp, err, attempt) || rewindBody(req) != nil {
+			return resp, err
+		}
+		closeBody(resp)
+		backoff := c.RetryPolicy.backoff(attempt)
+		if c.logger != nil {
+			u := *req.URL // sanitizeURL modifies the URL in place
+			c.logger.LogAttrs(ctx, slog.LevelInfo, "esa: retrying request",
+				slog.String("method", req.Method),
+				slog.String("url", sanitizeURL(&u).String()),
+				slog.Int("attempt", attempt),
+				slog.Duration("backoff", backoff),
+				slog.Any("error", err))
+		}
+		if serr := c.sleep(ctx, backoff); serr != nil {
+			return resp, serr
+		}
+	}
+}
+
+// doRateLimited sends an API request, sending it once more after the reset
+// if it was rejected by the rate limit and c.RateLimitPolicy allows waiting.
+func (c *Client) doRateLimited(ctx context.Context, req *http.Request) (*Response, error) {
+	resp, err := c.do(ctx, req)
+	if rerr, ok := err.(*RateLimitError); ok && c.RateLimitPolicy != RateLimitFailFast &&
+		rerr.Rate.Remaining == 0 && time.Now().Before(rerr.Rate.Reset.Time) {
+		// esa rejected the request, probably because the rate limit is
+		// shared with other clients. Wait for the reset and try once more.
+		if rewindBody(req) != nil {
+			return resp, rerr
+		}
+		closeBody(resp)
+		return c.do(ctx, req)
+	}
+	return resp, err
+}
+
+// do sends an API request once. See Do for details.
+func (c *Client) do(ctx context.Context, req *http.Request) (*Response, error) {
+	// If we've hit rate limit, wait for Reset time when the policy allows it,
+	// otherwise don't make further requests before Reset time.
+	waited, err := c.waitRateLimit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !waited {
+		if err := c.checkRateLimitBeforeDo(req); err != nil {
+			return &Response{
+				Response: err.Response,
+				Rate:     err.Rate,
+			}, err
+		}
+	}
+
+	release := func() {}
+	if c.scheduler != nil {
+		if err := c.scheduler.acquire(ctx); err != nil {
+			return nil, err
+		}
+		release = c.scheduler.release
 	}
 
-	req = req.WithContext(ctx)
 	resp, err := c.client.Do(req)
 	if err != nil {
+		release()
 		// If we got an error, and the context has been canceled,
 		// the context's error is probably more useful.
 		select {
@@ -206,41 +458,25 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 		return nil, err
 	}
 
-	defer func() {
-		// Drain up to 512 bytes and close the body to let the Transport reuse the connection
-		if _, err = io.CopyN(ioutil.Discard, resp.Body, 512); err != nil {
-			c.err = err
-		}
-		if err = resp.Body.Close(); err != nil {
-			c.err = err
-		}
-	}()
+	if c.scheduler != nil {
+		// Hold the slot until the body is downloaded.
+		resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+	}
 
 	response := newResponse(resp)
 
-	c.rateMu.Lock()
-	c.rateLimit = response.Rate
-	c.rateMu.Unlock()
+	c.setRate(response.Rate)
 
-	err = CheckResponse(resp)
-	if err != nil {
-		// even though there was an error, we still return the response
-		// in case the caller wants to inspect it further
-		return response, err
-	}
+	return response, CheckResponse(resp)
+}
 
-	if v != nil {
-		if w, ok := v.(io.Writer); ok {
-			_, err = io.Copy(w, resp.Body)
-		} else {
-			err = json.NewDecoder(resp.Body).Decode(v)
-			if err == io.EOF {
-				err = nil // ignore EOF errors caused by empty response body
-			}
-		}
+// closeBody drains and closes the body of a response which is discarded.
+func closeBody(resp *Response) {
+	if resp == nil || resp.Response == nil || resp.Body == nil {
+		return
 	}
-
-	return response, err
+	_, _ = io.CopyN(ioutil.Discard, resp.Body, 512)
+	_ = resp.Body.Close()
 }
 
 // checkRateLimitBeforeDo does not make any network calls, but uses existing knowledge from
@@ -284,6 +520,22 @@ func sanitizeURL(uri *url.URL) *url.URL {
 	return uri
 }
 
+// Sentinel errors matched by the errors returned from the API, so that the
+// kind of an error can be checked with errors.Is:
+//
+//	if errors.Is(err, esa.ErrNotFound) {
+//		// ...
+//	}
+var (
+	ErrValidation   = errors.New("esa: validation failed")   // 400 Bad Request, 422 Unprocessable Entity
+	ErrUnauthorized = errors.New("esa: unauthorized")        // 401 Unauthorized
+	ErrForbidden    = errors.New("esa: forbidden")           // 403 Forbidden
+	ErrNotFound     = errors.New("esa: not found")           // 404 Not Found
+	ErrConflict     = errors.New("esa: conflict")            // 409 Conflict
+	ErrRateLimited  = errors.New("esa: rate limit exceeded") // 429 Too Many Requests
+	ErrServer       = errors.New("esa: server error")        // 5xx
+)
+
 // An ErrorResponse reports one or more errors caused by an API request.
 type ErrorResponse struct {
 	Response *http.Response // HTTP response that caused this error
@@ -298,6 +550,37 @@ func (r *ErrorResponse) Error() string {
 		r.Response.StatusCode, r.Message, r.ErrorStr)
 }
 
+// Is reports whether the status code of the response corresponds to target,
+// which is one of the sentinel errors such as ErrNotFound.
+func (r *ErrorResponse) Is(target error) bool {
+	if r.Response == nil {
+		return false
+	}
+	switch c := r.Response.StatusCode; target {
+	case ErrValidation:
+		return c == http.StatusBadRequest || c == http.StatusUnprocessableEntity
+	case ErrUnauthorized:
+		return c == http.StatusUnauthorized
+	case ErrForbidden:
+		return c == http.StatusForbidden
+	case ErrNotFound:
+		return c == http.StatusNotFound
+	case ErrConflict:
+		return c == http.StatusConflict
+	case ErrRateLimited:
+		return c == http.StatusTooManyRequests
+	case ErrServer:
+		return c >= 500 && c < 600
+	}
+	return false
+}
+
+// Unwrap returns the error which occurred while reading or decoding the
+// response body, if any.
+func (r *ErrorResponse) Unwrap() error {
+	return r.err
+}
+
 // RateLimitError occurs when esa returns 429 Too Many Requests response with a rate limit
 // remaining value of 0, and error message starts with "API rate limit exceeded for ".
 type RateLimitError struct {
@@ -312,6 +595,11 @@ func (r *RateLimitError) Error() string {
 		r.Response.StatusCode, r.Message, r.Rate.Reset.Time.Sub(time.Now()))
 }
 
+// Is reports whether target is ErrRateLimited.
+func (r *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
 // CheckResponse checks the API response for errors, and returns them if
 // present. A response is considered an error if it has a status code not
 // equal to 200, 201, or 204.
@@ -324,10 +612,15 @@ func CheckResponse(r *http.Response) error {
 	}
 	errorResponse := &ErrorResponse{Response: r}
 	data, err := ioutil.ReadAll(r.Body)
-	if err == nil && data != nil {
-		err = json.Unmarshal(data, errorResponse)
-		if err != nil {
-			errorResponse.err = errors.New("Not JSON")
+	// Restore the body for those inspecting the response later, e.g. the
+	// logger dumping it.
+	r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		errorResponse.err = err
+	} else if data != nil {
+		if err := json.Unmarshal(data, errorResponse); err != nil {
+			errorResponse.err = fmt.Errorf("esa: response body is not JSON: %w", err)
 		}
 	}
 	switch r.StatusCode {
@@ -341,3 +634,7 @@ func CheckResponse(r *http.Response) error {
 		return errorResponse
 	}
 }
+
+// Bool is a helper routine that allocates a new bool value
+// to store v and returns a pointer to it.
+func Bool(v bool) *bool { return &v }
@@ -0,0 +1,164 @@
+package esa
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"sort"
+	"strings"
+)
+
+// AttachmentsService provides access to the attachment related functions
+// in the esa API.
+type AttachmentsService service
+
+type attachmentPolicyRequest struct {
+	Type string `json:"type"`
+	Size int    `json:"size"`
+	Name string `json:"name"`
+}
+
+// attachmentPolicy represents the policy to upload a file to the storage
+// of esa.
+type attachmentPolicy struct {
+	Attachment struct {
+		Endpoint string `json:"endpoint"`
+		URL      string `json:"url"`
+	} `json:"attachment"`
+	Form map[string]string `json:"form"`
+}
+
+// Upload uploads a file read from r and returns its URL, which can be
+// embedded in the body of posts and comments.
+//
+// The upload is done in two steps: an upload policy is requested from esa,
+// then the file is posted to the storage endpoint given by the policy. The
+// returned Response is the one of the last request sent.
+//
+// The access token is not sent to the storage, which is another host. The
+// file is posted with the http.Client of the API, with the Base of its
+// TokenTransport if it has one. A transport of any other type, which may
+// authenticate, is replaced by http.DefaultTransport, in which case the
+// client to use can be given with WithStorageClient.
+//
+// The attachments endpoint has no section in the API docs at
+// https://docs.esa.io/posts/102.
+func (s *AttachmentsService) Upload(ctx context.Context, team, name, contentType string, r io.Reader) (string, *Response, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", nil, err
+	}
+
+	u := fmt.Sprintf("teams/%s/attachments/policies", s.client.teamName(team))
+	req, err := s.client.NewRequest("POST", u, &attachmentPolicyRequest{
+		Type: contentType,
+		Size: len(data),
+		Name: name,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	p := &attachmentPolicy{}
+	resp, err := s.client.Do(ctx, req, p)
+	if err != nil {
+		return "", resp, err
+	}
+
+	req, err = newUploadRequest(p, name, contentType, data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	hresp, err := s.client.storageClient().Do(req.WithContext(ctx))
+	if err != nil {
+		select {
+		case <-ctx.Done():
+			return "", nil, ctx.Err()
+		default:
+		}
+		return "", nil, err
+	}
+	defer func() {
+		// Drain up to 512 bytes and close the body to let the Transport reuse the connection
+		_, _ = io.CopyN(ioutil.Discard, hresp.Body, 512)
+		_ = hresp.Body.Close()
+	}()
+
+	resp = newResponse(hresp)
+	if err := CheckResponse(hresp); err != nil {
+		return "", resp, err
+	}
+	return p.Attachment.URL, resp, nil
+}
+
+// newUploadRequest returns a multipart request posting data to the endpoint
+// of p, along with the form fields the storage requires.
+func newUploadRequest(p *attachmentPolicy, name, contentType string, data []byte) (*http.Request, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	keys := make([]string, 0, len(p.Form))
+	for k := range p.Form {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := w.WriteField(k, p.Form[k]); err != nil {
+			return nil, err
+		}
+	}
+
+	// The file must be the last field of the form.
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, escapeQuotes(name)))
+	h.Set("Content-Type", contentType)
+	fw, err := w.CreatePart(h)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", p.Attachment.Endpoint, &buf)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req, nil
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+// storageClient returns the HTTP client used to send requests to the storage
+// of esa, which is the client of the API without the access token. See
+// AttachmentsService.Upload.
+func (c *Client) storageClient() *http.Client {
+	if c.storage != nil {
+		return c.storage
+	}
+	hc := *c.client
+	switch tr := hc.Transport.(type) {
+	case nil, *http.Transport:
+		// It does not authenticate.
+	case *TokenTransport:
+		hc.Transport = tr.base()
+	default:
+		// It may authenticate, and cannot be unwrapped.
+		hc.Transport = http.DefaultTransport
+	}
+	return &hc
+}
@@ -0,0 +1,214 @@
+package esa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAttachmentsService_Upload(t *testing.T) {
+	setup()
+	defer teardown()
+
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("Authorization is %v, want the token not to be sent to the storage", got)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm returned error: %v", err)
+		}
+		for k, want := range map[string]string{"key": "uploads/image.png", "policy": "policy"} {
+			if got := r.FormValue(k); got != want {
+				t.Errorf("Form value %v is %v, want %v", k, got, want)
+			}
+		}
+		f, h, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("FormFile returned error: %v", err)
+		}
+		if got, want := h.Filename, "image.png"; got != want {
+			t.Errorf("Filename is %v, want %v", got, want)
+		}
+		if got, want := h.Header.Get("Content-Type"), "image/png"; got != want {
+			t.Errorf("Content-Type is %v, want %v", got, want)
+		}
+		if data, _ := ioutil.ReadAll(f); string(data) != "image" {
+			t.Errorf("File content is %q, want %q", data, "image")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer storage.Close()
+
+	mux.HandleFunc("/v1/teams/hoge/attachments/policies", func(w http.ResponseWriter, r *http.Request) {
+		v := new(attachmentPolicyRequest)
+		json.NewDecoder(r.Body).Decode(v)
+
+		testMethod(t, r, "POST")
+		if got, want := r.Header.Get("Authorization"), "Bearer token"; got != want {
+			t.Errorf("Authorization is %v, want %v", got, want)
+		}
+		want := &attachmentPolicyRequest{Type: "image/png", Size: 5, Name: "image.png"}
+		if !reflect.DeepEqual(v, want) {
+			t.Errorf("Request body = %+v, want %+v", v, want)
+		}
+		fmt.Fprintf(w, `{
+  "attachment": {
+    "endpoint": "%s",
+    "url": "https://img.esa.io/uploads/production/attachments/105/2016/09/05/1/image.png"
+  },
+  "form": {
+    "key": "uploads/image.png",
+    "policy": "policy"
+  }
+}`, storage.URL)
+	})
+
+	client.client = (&TokenTransport{Token: "token"}).Client()
+	u, _, err := client.Attachments.Upload(context.Background(), "hoge", "image.png", "image/png", strings.NewReader("image"))
+	if err != nil {
+		t.Errorf("Attachments.Upload returned error: %v", err)
+	}
+
+	if want := "https://img.esa.io/uploads/production/attachments/105/2016/09/05/1/image.png"; u != want {
+		t.Errorf("AttachmentsService.Upload returned %v, want %v", u, want)
+	}
+}
+
+func TestAttachmentsService_Upload_authTransport(t *testing.T) {
+	setup()
+	defer teardown()
+
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("Authorization is %v, want the token not to be sent to the storage", got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer storage.Close()
+
+	mux.HandleFunc("/v1/teams/hoge/attachments/policies", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"attachment": {"endpoint": "%s", "url": "https://img.esa.io/image.png"}, "form": {}}`, storage.URL)
+	})
+
+	// An authenticating transport other than TokenTransport.
+	client.client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer token")
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+	if _, _, err := client.Attachments.Upload(context.Background(), "hoge", "image.png", "image/png", strings.NewReader("image")); err != nil {
+		t.Errorf("Attachments.Upload returned error: %v", err)
+	}
+}
+
+func TestAttachmentsService_Upload_tokenTransportBase(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/attachments/policies", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"attachment": {"endpoint": "https://storage.example.com/", "url": "https://img.esa.io/image.png"}, "form": {}}`)
+	})
+
+	var got *http.Request
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Host != "storage.example.com" {
+			return http.DefaultTransport.RoundTrip(req)
+		}
+		got = req
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			Request:    req,
+		}, nil
+	})
+	client.client = (&TokenTransport{Token: "token", Base: base}).Client()
+
+	if _, _, err := client.Attachments.Upload(context.Background(), "hoge", "image.png", "image/png", strings.NewReader("image")); err != nil {
+		t.Errorf("Attachments.Upload returned error: %v", err)
+	}
+	if got == nil {
+		t.Fatal("Storage was not sent a request by the Base of TokenTransport")
+	}
+	if auth := got.Header.Get("Authorization"); auth != "" {
+		t.Errorf("Authorization is %v, want the token not to be sent to the storage", auth)
+	}
+}
+
+func TestWithStorageClient(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/attachments/policies", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"attachment": {"endpoint": "https://storage.example.com/", "url": "https://img.esa.io/image.png"}, "form": {}}`)
+	})
+
+	var got string
+	WithStorageClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		got = req.URL.String()
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			Request:    req,
+		}, nil
+	})})(client)
+
+	if _, _, err := client.Attachments.Upload(context.Background(), "hoge", "image.png", "image/png", strings.NewReader("image")); err != nil {
+		t.Errorf("Attachments.Upload returned error: %v", err)
+	}
+	if want := "https://storage.example.com/"; got != want {
+		t.Errorf("Storage client was sent %v, want %v", got, want)
+	}
+}
+
+func TestAttachmentsService_Upload_storageError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>AccessDenied</Code></Error>`)
+	}))
+	defer storage.Close()
+
+	mux.HandleFunc("/v1/teams/hoge/attachments/policies", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"attachment": {"endpoint": "%s", "url": "https://img.esa.io/image.png"}, "form": {}}`, storage.URL)
+	})
+
+	u, resp, err := client.Attachments.Upload(context.Background(), "hoge", "image.png", "image/png", strings.NewReader("image"))
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+	if u != "" {
+		t.Errorf("AttachmentsService.Upload returned %v, want empty", u)
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("AttachmentsService.Upload returned Response %v, want the storage response", resp)
+	}
+}
+
+func TestAttachmentsService_Upload_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/attachments/policies", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+	})
+
+	_, resp, err := client.Attachments.Upload(context.Background(), "hoge", "image.png", "image/png", strings.NewReader("image"))
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("AttachmentsService.Upload returned Reponse, too")
+	}
+}
@@ -0,0 +1,55 @@
+package esa
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// CategoriesService provides access to the category related functions
+// in the esa API.
+//
+// API docs: https://docs.esa.io/posts/102#11-0-0
+type CategoriesService service
+
+// BatchMoveResult represents the result of moving categories.
+type BatchMoveResult struct {
+	Count int    `json:"count"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+func (r BatchMoveResult) String() string {
+	return Stringify(r)
+}
+
+type batchMoveRequest struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// BatchMove moves all posts under the category from to the category to,
+// keeping the hierarchy of their sub categories.
+// Both categories must be absolute paths such as "/foo/bar/".
+//
+// API docs: https://docs.esa.io/posts/102#11-1-0
+func (s *CategoriesService) BatchMove(ctx context.Context, team, from, to string) (*BatchMoveResult, *Response, error) {
+	for _, c := range []string{from, to} {
+		if !strings.HasPrefix(c, "/") {
+			return nil, nil, fmt.Errorf("esa: category %q must be an absolute path", c)
+		}
+	}
+
+	u := fmt.Sprintf("teams/%s/categories/batch_move", s.client.teamName(team))
+	req, err := s.client.NewRequest("POST", u, &batchMoveRequest{From: from, To: to})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &BatchMoveResult{}
+	resp, err := s.client.Do(ctx, req, r)
+	if err != nil {
+		return nil, resp, err
+	}
+	return r, resp, nil
+}
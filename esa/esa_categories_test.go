@@ -0,0 +1,79 @@
+package esa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCategoriesService_BatchMove(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/categories/batch_move", func(w http.ResponseWriter, r *http.Request) {
+		v := new(batchMoveRequest)
+		json.NewDecoder(r.Body).Decode(v)
+
+		testMethod(t, r, "POST")
+		want := &batchMoveRequest{From: "/foo/bar/", To: "/baz/"}
+		if !reflect.DeepEqual(v, want) {
+			t.Errorf("Request body = %+v, want %+v", v, want)
+		}
+		fmt.Fprint(w, `{"count": 3, "from": "/foo/bar/", "to": "/baz/"}`)
+	})
+
+	result, _, err := client.Categories.BatchMove(context.Background(), "hoge", "/foo/bar/", "/baz/")
+	if err != nil {
+		t.Errorf("Categories.BatchMove returned error: %v", err)
+	}
+
+	want := &BatchMoveResult{Count: 3, From: "/foo/bar/", To: "/baz/"}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("CategoriesService.BatchMove returned %+v, want %+v", result, want)
+	}
+}
+
+func TestCategoriesService_BatchMove_relativePath(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/categories/batch_move", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("BatchMove sent a request with a relative category")
+	})
+
+	tests := []struct{ from, to string }{
+		{"foo/bar/", "/baz/"},
+		{"/foo/bar/", "baz/"},
+		{"", "/baz/"},
+	}
+	for _, tt := range tests {
+		_, resp, err := client.Categories.BatchMove(context.Background(), "hoge", tt.from, tt.to)
+		if err == nil {
+			t.Errorf("BatchMove(%q, %q) expected error to be returned.", tt.from, tt.to)
+		}
+		if resp != nil {
+			t.Errorf("BatchMove(%q, %q) returned Response %v, want nil", tt.from, tt.to, resp)
+		}
+	}
+}
+
+func TestCategoriesService_BatchMove_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/categories/batch_move", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+	})
+
+	_, resp, err := client.Categories.BatchMove(context.Background(), "hoge", "/foo/bar/", "/baz/")
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("CategoriesService.BatchMove returned Reponse, too")
+	}
+}
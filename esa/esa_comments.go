@@ -0,0 +1,205 @@
+package esa
+
+import (
+	"context"
+	"fmt"
+)
+
+// CommentsService provides access to the comment related functions
+// in the esa API.
+//
+// API docs: https://docs.esa.io/posts/102#8-0-0
+type CommentsService service
+
+// Comment represents a comment on a post.
+type Comment struct {
+	ID              int       `json:"id"`
+	BodyMD          string    `json:"body_md"`
+	BodyHTML        string    `json:"body_html"`
+	CreatedAt       Timestamp `json:"created_at"`
+	UpdatedAt       Timestamp `json:"updated_at"`
+	URL             string    `json:"url"`
+	CreatedBy       *Author   `json:"created_by"`
+	StargazersCount int       `json:"stargazers_count"`
+	Star            bool      `json:"star"`
+}
+
+func (c Comment) String() string {
+	return Stringify(c)
+}
+
+// CommentList represents a list of comments.
+type CommentList struct {
+	Comments   []*Comment `json:"comments"`
+	PrevPage   int        `json:"prev_page"`
+	NextPage   int        `json:"next_page"`
+	TotalCount int        `json:"total_count"`
+	Page       int        `json:"page"`
+	PerPage    int        `json:"per_page"`
+	MaxPerPage int        `json:"max_per_page"`
+}
+
+func (l CommentList) String() string {
+	return Stringify(l)
+}
+
+// CommentRequest represents a comment to be created or updated.
+type CommentRequest struct {
+	BodyMD string `json:"body_md"`
+}
+
+func (r CommentRequest) String() string {
+	return Stringify(r)
+}
+
+// commentRequest is the request body of creating and updating a comment.
+type commentRequest struct {
+	Comment *CommentRequest `json:"comment"`
+}
+
+// ListByPost lists comments on a post.
+//
+// API docs: https://docs.esa.io/posts/102#8-1-0
+func (s *CommentsService) ListByPost(ctx context.Context, team string, number int, opts *ListOptions) (*CommentList, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("teams/%s/posts/%d/comments", s.client.teamName(team), number), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	l := &CommentList{}
+	resp, err := s.client.Do(ctx, req, l)
+	if err != nil {
+		return nil, resp, err
+	}
+	return l, resp, nil
+}
+
+// ListAllByPost returns an Iterator over all comments on a post, starting
+// from the page specified by opts.
+func (s *CommentsService) ListAllByPost(team string, number int, opts *ListOptions) *Iterator[*Comment] {
+	o := ListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return newIterator(o.Page, func(ctx context.Context, page int) ([]*Comment, int, *Response, error) {
+		o.Page = page
+		l, resp, err := s.ListByPost(ctx, team, number, &o)
+		if err != nil {
+			return nil, 0, resp, err
+		}
+		return l.Comments, l.NextPage, resp, nil
+	})
+}
+
+// List lists all comments in a team.
+//
+// API docs: https://docs.esa.io/posts/102#8-6-0
+func (s *CommentsService) List(ctx context.Context, team string, opts *ListOptions) (*CommentList, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("teams/%s/comments", s.client.teamName(team)), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	l := &CommentList{}
+	resp, err := s.client.Do(ctx, req, l)
+	if err != nil {
+		return nil, resp, err
+	}
+	return l, resp, nil
+}
+
+// ListAll returns an Iterator over all comments in a team, starting from the
+// page specified by opts.
+func (s *CommentsService) ListAll(team string, opts *ListOptions) *Iterator[*Comment] {
+	o := ListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return newIterator(o.Page, func(ctx context.Context, page int) ([]*Comment, int, *Response, error) {
+		o.Page = page
+		l, resp, err := s.List(ctx, team, &o)
+		if err != nil {
+			return nil, 0, resp, err
+		}
+		return l.Comments, l.NextPage, resp, nil
+	})
+}
+
+// Get fetches a comment by id.
+//
+// API docs: https://docs.esa.io/posts/102#8-2-0
+func (s *CommentsService) Get(ctx context.Context, team string, id int) (*Comment, *Response, error) {
+	u := fmt.Sprintf("teams/%s/comments/%d", s.client.teamName(team), id)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := &Comment{}
+	resp, err := s.client.Do(ctx, req, c)
+	if err != nil {
+		return nil, resp, err
+	}
+	return c, resp, nil
+}
+
+// Create creates a new comment on a post.
+//
+// API docs: https://docs.esa.io/posts/102#8-3-0
+func (s *CommentsService) Create(ctx context.Context, team string, number int, comment *CommentRequest) (*Comment, *Response, error) {
+	u := fmt.Sprintf("teams/%s/posts/%d/comments", s.client.teamName(team), number)
+	req, err := s.client.NewRequest("POST", u, &commentRequest{Comment: comment})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := &Comment{}
+	resp, err := s.client.Do(ctx, req, c)
+	if err != nil {
+		return nil, resp, err
+	}
+	return c, resp, nil
+}
+
+// Update updates a comment by id.
+//
+// API docs: https://docs.esa.io/posts/102#8-4-0
+func (s *CommentsService) Update(ctx context.Context, team string, id int, comment *CommentRequest) (*Comment, *Response, error) {
+	u := fmt.Sprintf("teams/%s/comments/%d", s.client.teamName(team), id)
+	req, err := s.client.NewRequest("PATCH", u, &commentRequest{Comment: comment})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := &Comment{}
+	resp, err := s.client.Do(ctx, req, c)
+	if err != nil {
+		return nil, resp, err
+	}
+	return c, resp, nil
+}
+
+// Delete deletes a comment by id.
+//
+// API docs: https://docs.esa.io/posts/102#8-5-0
+func (s *CommentsService) Delete(ctx context.Context, team string, id int) (*Response, error) {
+	u := fmt.Sprintf("teams/%s/comments/%d", s.client.teamName(team), id)
+	req, err := s.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
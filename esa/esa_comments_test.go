@@ -0,0 +1,308 @@
+package esa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const commentJSON = `{
+  "id": 13,
+  "body_md": "読みたい",
+  "body_html": "<p>読みたい</p>",
+  "created_at": "2014-05-10T12:45:42+09:00",
+  "updated_at": "2014-05-18T23:02:29+09:00",
+  "url": "https://docs.esa.io/posts/2#comment-13",
+  "created_by": {
+    "myself": false,
+    "name": "TAEKO AKATSUKA",
+    "screen_name": "taea",
+    "icon": "https://img.esa.io/uploads/production/users/2/icon/thumb_s_2690997f07b7de3014a36d90827603d6.jpg"
+  },
+  "stargazers_count": 0,
+  "star": false
+}`
+
+var wantComment = &Comment{
+	ID:        13,
+	BodyMD:    "読みたい",
+	BodyHTML:  "<p>読みたい</p>",
+	CreatedAt: Timestamp{time.Date(2014, 5, 10, 12, 45, 42, 0, jst).Local()},
+	UpdatedAt: Timestamp{time.Date(2014, 5, 18, 23, 2, 29, 0, jst).Local()},
+	URL:       "https://docs.esa.io/posts/2#comment-13",
+	CreatedBy: &Author{
+		Myself:     false,
+		Name:       "TAEKO AKATSUKA",
+		ScreenName: "taea",
+		Icon:       "https://img.esa.io/uploads/production/users/2/icon/thumb_s_2690997f07b7de3014a36d90827603d6.jpg",
+	},
+	StargazersCount: 0,
+	Star:            false,
+}
+
+var commentListJSON = fmt.Sprintf(`{
+  "comments": [%s],
+  "prev_page": null,
+  "next_page": null,
+  "total_count": 1,
+  "page": 1,
+  "per_page": 20,
+  "max_per_page": 100
+}`, commentJSON)
+
+var wantCommentList = &CommentList{
+	Comments:   []*Comment{wantComment},
+	PrevPage:   0,
+	NextPage:   0,
+	TotalCount: 1,
+	Page:       1,
+	PerPage:    20,
+	MaxPerPage: 100,
+}
+
+func TestCommentsService_ListByPost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/2/comments", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{})
+		fmt.Fprint(w, commentListJSON)
+	})
+
+	list, _, err := client.Comments.ListByPost(context.Background(), "hoge", 2, nil)
+	if err != nil {
+		t.Errorf("Comments.ListByPost returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(list, wantCommentList) {
+		t.Errorf("CommentsService.ListByPost returned %+v, want %+v", list, wantCommentList)
+	}
+}
+
+func TestCommentsService_ListByPost_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/2/comments", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	})
+
+	_, resp, err := client.Comments.ListByPost(context.Background(), "hoge", 2, nil)
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("CommentsService.ListByPost returned Reponse, too")
+	}
+}
+
+func TestCommentsService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/comments", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{})
+		fmt.Fprint(w, commentListJSON)
+	})
+
+	list, _, err := client.Comments.List(context.Background(), "hoge", nil)
+	if err != nil {
+		t.Errorf("Comments.List returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(list, wantCommentList) {
+		t.Errorf("CommentsService.List returned %+v, want %+v", list, wantCommentList)
+	}
+}
+
+func TestCommentsService_List_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/comments", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	})
+
+	_, resp, err := client.Comments.List(context.Background(), "hoge", nil)
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("CommentsService.List returned Reponse, too")
+	}
+}
+
+func TestCommentsService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/comments/13", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{})
+		fmt.Fprint(w, commentJSON)
+	})
+
+	c, _, err := client.Comments.Get(context.Background(), "hoge", 13)
+	if err != nil {
+		t.Errorf("Comments.Get returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(c, wantComment) {
+		t.Errorf("CommentsService.Get returned %+v, want %+v", c, wantComment)
+	}
+}
+
+func TestCommentsService_Get_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/comments/13", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	_, resp, err := client.Comments.Get(context.Background(), "hoge", 13)
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("CommentsService.Get returned Reponse, too")
+	}
+}
+
+func TestCommentsService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	input := &CommentRequest{BodyMD: "読みたい"}
+
+	mux.HandleFunc("/v1/teams/hoge/posts/2/comments", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		v := new(commentRequest)
+		json.NewDecoder(r.Body).Decode(v)
+		if !reflect.DeepEqual(v.Comment, input) {
+			t.Errorf("Request body = %+v, want %+v", v.Comment, input)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, commentJSON)
+	})
+
+	c, _, err := client.Comments.Create(context.Background(), "hoge", 2, input)
+	if err != nil {
+		t.Errorf("Comments.Create returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(c, wantComment) {
+		t.Errorf("CommentsService.Create returned %+v, want %+v", c, wantComment)
+	}
+}
+
+func TestCommentsService_Create_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/2/comments", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	})
+
+	_, resp, err := client.Comments.Create(context.Background(), "hoge", 2, &CommentRequest{})
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("CommentsService.Create returned Reponse, too")
+	}
+}
+
+func TestCommentsService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	input := &CommentRequest{BodyMD: "読みたい"}
+
+	mux.HandleFunc("/v1/teams/hoge/comments/13", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		v := new(commentRequest)
+		json.NewDecoder(r.Body).Decode(v)
+		if !reflect.DeepEqual(v.Comment, input) {
+			t.Errorf("Request body = %+v, want %+v", v.Comment, input)
+		}
+
+		fmt.Fprint(w, commentJSON)
+	})
+
+	c, _, err := client.Comments.Update(context.Background(), "hoge", 13, input)
+	if err != nil {
+		t.Errorf("Comments.Update returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(c, wantComment) {
+		t.Errorf("CommentsService.Update returned %+v, want %+v", c, wantComment)
+	}
+}
+
+func TestCommentsService_Update_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/comments/13", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	})
+
+	_, resp, err := client.Comments.Update(context.Background(), "hoge", 13, &CommentRequest{})
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("CommentsService.Update returned Reponse, too")
+	}
+}
+
+func TestCommentsService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/comments/13", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testFormValues(t, r, values{})
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	resp, err := client.Comments.Delete(context.Background(), "hoge", 13)
+	if err != nil {
+		t.Errorf("Comments.Delete returned error: %v", err)
+	}
+
+	if resp == nil {
+		t.Error("Comments.Delete returned Response.")
+	}
+}
+
+func TestCommentsService_Delete_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/comments/13", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	})
+
+	resp, err := client.Comments.Delete(context.Background(), "hoge", 13)
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("CommentsService.Delete returned Reponse.")
+	}
+}
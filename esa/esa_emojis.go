@@ -0,0 +1,142 @@
+package esa
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+)
+
+// EmojisService provides access to the custom emoji related functions
+// in the esa API.
+//
+// API docs: https://docs.esa.io/posts/102#14-0-0
+type EmojisService service
+
+// Emoji represents an emoji available in a team.
+type Emoji struct {
+	Code     string   `json:"code"`
+	Aliases  []string `json:"aliases"`
+	Category string   `json:"category"`
+	URL      string   `json:"url"`
+}
+
+func (e Emoji) String() string {
+	return Stringify(e)
+}
+
+// EmojiList represents a list of emojis.
+type EmojiList struct {
+	Emojis []*Emoji `json:"emojis"`
+}
+
+func (l EmojiList) String() string {
+	return Stringify(l)
+}
+
+// EmojiListOptions specifies the optional parameters to the
+// EmojisService.List method.
+type EmojiListOptions struct {
+	// Include adds emojis other than the custom ones of the team.
+	// Possible values are: all
+	Include string `url:"include,omitempty"`
+}
+
+// EmojiRequest represents a custom emoji to be created.
+// Either OriginURL or Image must be set.
+type EmojiRequest struct {
+	Code string
+
+	// OriginURL is the URL of the image to be fetched by esa.
+	OriginURL string
+
+	// Image is read to the end and uploaded as the image.
+	Image io.Reader
+}
+
+type emojiRequest struct {
+	Emoji *emojiBody `json:"emoji"`
+}
+
+type emojiBody struct {
+	Code      string `json:"code"`
+	OriginURL string `json:"origin_url,omitempty"`
+	Image     string `json:"image,omitempty"`
+}
+
+// List lists emojis of a team.
+//
+// API docs: https://docs.esa.io/posts/102#14-1-0
+func (s *EmojisService) List(ctx context.Context, team string, opts *EmojiListOptions) (*EmojiList, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("teams/%s/emojis", s.client.teamName(team)), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	l := &EmojiList{}
+	resp, err := s.client.Do(ctx, req, l)
+	if err != nil {
+		return nil, resp, err
+	}
+	return l, resp, nil
+}
+
+// Create creates a custom emoji.
+//
+// API docs: https://docs.esa.io/posts/102#14-2-0
+func (s *EmojisService) Create(ctx context.Context, team string, e *EmojiRequest) (*Emoji, *Response, error) {
+	if e == nil {
+		return nil, nil, errors.New("esa: emoji must not be nil")
+	}
+	b := &emojiBody{Code: e.Code, OriginURL: e.OriginURL}
+	switch {
+	case e.OriginURL != "" && e.Image != nil:
+		return nil, nil, errors.New("esa: emoji must have either OriginURL or Image, not both")
+	case e.Image != nil:
+		data, err := ioutil.ReadAll(e.Image)
+		if err != nil {
+			return nil, nil, err
+		}
+		b.Image = base64.StdEncoding.EncodeToString(data)
+	case e.OriginURL == "":
+		return nil, nil, errors.New("esa: emoji must have either OriginURL or Image")
+	}
+
+	u := fmt.Sprintf("teams/%s/emojis", s.client.teamName(team))
+	req, err := s.client.NewRequest("POST", u, &emojiRequest{Emoji: b})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	emoji := &Emoji{}
+	resp, err := s.client.Do(ctx, req, emoji)
+	if err != nil {
+		return nil, resp, err
+	}
+	return emoji, resp, nil
+}
+
+// Delete deletes a custom emoji by code.
+//
+// API docs: https://docs.esa.io/posts/102#14-3-0
+func (s *EmojisService) Delete(ctx context.Context, team string, code string) (*Response, error) {
+	u := fmt.Sprintf("teams/%s/emojis/%s", s.client.teamName(team), url.PathEscape(code))
+	req, err := s.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,189 @@
+package esa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEmojisService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/emojis", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"include": "all"})
+		fmt.Fprint(w, `{
+  "emojis": [
+    {
+      "code": "team_emoji",
+      "aliases": [
+        "team_emoji"
+      ],
+      "category": "Custom",
+      "url": "https://img.esa.io/uploads/production/emojis/6/icon/thumb_m_team_emoji.png"
+    },
+    {
+      "code": "+1",
+      "aliases": [
+        "+1",
+        "thumbsup"
+      ],
+      "category": "People",
+      "url": "https://assets.esa.io/images/emoji/unicode/1f44d.png"
+    }
+  ]
+}`)
+	})
+
+	list, _, err := client.Emojis.List(context.Background(), "hoge", &EmojiListOptions{Include: "all"})
+	if err != nil {
+		t.Errorf("Emojis.List returned error: %v", err)
+	}
+
+	want := &EmojiList{
+		Emojis: []*Emoji{
+			{
+				Code:     "team_emoji",
+				Aliases:  []string{"team_emoji"},
+				Category: "Custom",
+				URL:      "https://img.esa.io/uploads/production/emojis/6/icon/thumb_m_team_emoji.png",
+			},
+			{
+				Code:     "+1",
+				Aliases:  []string{"+1", "thumbsup"},
+				Category: "People",
+				URL:      "https://assets.esa.io/images/emoji/unicode/1f44d.png",
+			},
+		},
+	}
+	if !reflect.DeepEqual(list, want) {
+		t.Errorf("EmojisService.List returned %+v, want %+v", list, want)
+	}
+}
+
+func TestEmojisService_List_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/emojis", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	_, resp, err := client.Emojis.List(context.Background(), "hoge", nil)
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("EmojisService.List returned Reponse, too")
+	}
+}
+
+func TestEmojisService_Create_originURL(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/emojis", func(w http.ResponseWriter, r *http.Request) {
+		v := new(emojiRequest)
+		json.NewDecoder(r.Body).Decode(v)
+
+		testMethod(t, r, "POST")
+		want := &emojiRequest{Emoji: &emojiBody{Code: "team_emoji", OriginURL: "https://example.com/emoji.png"}}
+		if !reflect.DeepEqual(v, want) {
+			t.Errorf("Request body = %+v, want %+v", v, want)
+		}
+		fmt.Fprint(w, `{"code": "team_emoji"}`)
+	})
+
+	input := &EmojiRequest{Code: "team_emoji", OriginURL: "https://example.com/emoji.png"}
+	emoji, _, err := client.Emojis.Create(context.Background(), "hoge", input)
+	if err != nil {
+		t.Errorf("Emojis.Create returned error: %v", err)
+	}
+
+	want := &Emoji{Code: "team_emoji"}
+	if !reflect.DeepEqual(emoji, want) {
+		t.Errorf("EmojisService.Create returned %+v, want %+v", emoji, want)
+	}
+}
+
+func TestEmojisService_Create_image(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/emojis", func(w http.ResponseWriter, r *http.Request) {
+		v := new(emojiRequest)
+		json.NewDecoder(r.Body).Decode(v)
+
+		testMethod(t, r, "POST")
+		want := &emojiRequest{Emoji: &emojiBody{Code: "team_emoji", Image: "aW1hZ2U="}}
+		if !reflect.DeepEqual(v, want) {
+			t.Errorf("Request body = %+v, want %+v", v, want)
+		}
+		fmt.Fprint(w, `{"code": "team_emoji"}`)
+	})
+
+	input := &EmojiRequest{Code: "team_emoji", Image: strings.NewReader("image")}
+	_, _, err := client.Emojis.Create(context.Background(), "hoge", input)
+	if err != nil {
+		t.Errorf("Emojis.Create returned error: %v", err)
+	}
+}
+
+func TestEmojisService_Create_invalidImage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tests := []*EmojiRequest{
+		nil,
+		{Code: "team_emoji"},
+		{Code: "team_emoji", OriginURL: "https://example.com/emoji.png", Image: strings.NewReader("image")},
+	}
+	for _, input := range tests {
+		_, resp, err := client.Emojis.Create(context.Background(), "hoge", input)
+		if err == nil {
+			t.Errorf("Emojis.Create(%+v) expected error to be returned.", input)
+		}
+		if resp != nil {
+			t.Errorf("Emojis.Create(%+v) returned Response %v, want nil", input, resp)
+		}
+	}
+}
+
+func TestEmojisService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/emojis/team_emoji", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Emojis.Delete(context.Background(), "hoge", "team_emoji")
+	if err != nil {
+		t.Errorf("Emojis.Delete returned error: %v", err)
+	}
+}
+
+func TestEmojisService_Delete_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/emojis/team_emoji", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	resp, err := client.Emojis.Delete(context.Background(), "hoge", "team_emoji")
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("EmojisService.Delete returned Reponse, too")
+	}
+}
@@ -67,7 +67,7 @@ type InvitationEmails struct {
 //
 // API docs: https://docs.esa.io/posts/102#12-1-0
 func (s *InvitationsService) GetURL(ctx context.Context, team string) (*InvitationURL, *Response, error) {
-	u := fmt.Sprintf("teams/%s/invitation", team)
+	u := fmt.Sprintf("teams/%s/invitation", s.client.teamName(team))
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
@@ -85,7 +85,7 @@ func (s *InvitationsService) GetURL(ctx context.Context, team string) (*Invitati
 //
 // API docs: https://docs.esa.io/posts/102#12-2-0
 func (s *InvitationsService) RegenerateURL(ctx context.Context, team string) (*InvitationURL, *Response, error) {
-	u := fmt.Sprintf("teams/%s/invitation_regenerator", team)
+	u := fmt.Sprintf("teams/%s/invitation_regenerator", s.client.teamName(team))
 	req, err := s.client.NewRequest("POST", u, nil)
 	if err != nil {
 		return nil, nil, err
@@ -103,7 +103,7 @@ func (s *InvitationsService) RegenerateURL(ctx context.Context, team string) (*I
 //
 // API docs: https://docs.esa.io/posts/102#12-1-0
 func (s *InvitationsService) SendToMember(ctx context.Context, team string, member *InvitationMember) (*InvitationList, *Response, error) {
-	u := fmt.Sprintf("teams/%s/invitations", team)
+	u := fmt.Sprintf("teams/%s/invitations", s.client.teamName(team))
 	req, err := s.client.NewRequest("POST", u, member)
 	if err != nil {
 		return nil, nil, err
@@ -120,8 +120,11 @@ func (s *InvitationsService) SendToMember(ctx context.Context, team string, memb
 // PendingInvitations fetches a list of pending invitations.
 //
 // API docs: https://docs.esa.io/posts/102#13-2-0
-func (s *InvitationsService) PendingInvitations(ctx context.Context, team string) (*InvitationList, *Response, error) {
-	u := fmt.Sprintf("teams/%s/invitations", team)
+func (s *InvitationsService) PendingInvitations(ctx context.Context, team string, opts *ListOptions) (*InvitationList, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("teams/%s/invitations", s.client.teamName(team)), opts)
+	if err != nil {
+		return nil, nil, err
+	}
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
@@ -135,11 +138,28 @@ func (s *InvitationsService) PendingInvitations(ctx context.Context, team string
 	return l, resp, nil
 }
 
+// AllPendingInvitations returns an Iterator over all pending invitations,
+// starting from the page specified by opts.
+func (s *InvitationsService) AllPendingInvitations(team string, opts *ListOptions) *Iterator[*Invitation] {
+	o := ListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return newIterator(o.Page, func(ctx context.Context, page int) ([]*Invitation, int, *Response, error) {
+		o.Page = page
+		l, resp, err := s.PendingInvitations(ctx, team, &o)
+		if err != nil {
+			return nil, 0, resp, err
+		}
+		return l.Invitations, l.NextPage, resp, nil
+	})
+}
+
 // Cancel deletes an invitation by an invitation code.
 //
 // API docs: https://docs.esa.io/posts/102#13-3-0
 func (s *InvitationsService) Cancel(ctx context.Context, team string, code string) (*Response, error) {
-	u := fmt.Sprintf("teams/%s/invitations/%s", team, code)
+	u := fmt.Sprintf("teams/%s/invitations/%s", s.client.teamName(team), code)
 	req, err := s.client.NewRequest("DELETE", u, nil)
 	if err != nil {
 		return nil, err
@@ -207,7 +207,7 @@ func TestInvitationsService_PendingInvitations(t *testing.T) {
 	}`)
 	})
 
-	l, _, err := client.Invitations.PendingInvitations(context.Background(), "hoge")
+	l, _, err := client.Invitations.PendingInvitations(context.Background(), "hoge", nil)
 	if err != nil {
 		t.Errorf("Invitations.PendingInvitations returned error: %v", err)
 	}
@@ -239,6 +239,22 @@ func TestInvitationsService_PendingInvitations(t *testing.T) {
 	}
 }
 
+func TestInvitationsService_PendingInvitations_withOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/invitations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"page": "2", "per_page": "100"})
+		fmt.Fprint(w, `{"invitations": []}`)
+	})
+
+	_, _, err := client.Invitations.PendingInvitations(context.Background(), "hoge", &ListOptions{Page: 2, PerPage: 100})
+	if err != nil {
+		t.Errorf("Invitations.PendingInvitations returned error: %v", err)
+	}
+}
+
 func TestInvitationsService_PendingInvitations_ErrorStatus(t *testing.T) {
 	setup()
 	defer teardown()
@@ -247,7 +263,7 @@ func TestInvitationsService_PendingInvitations_ErrorStatus(t *testing.T) {
 		http.Error(w, "Forbidden", http.StatusForbidden)
 	})
 
-	_, resp, err := client.Invitations.PendingInvitations(context.Background(), "hoge")
+	_, resp, err := client.Invitations.PendingInvitations(context.Background(), "hoge", nil)
 	if err == nil {
 		t.Error("Expected error to be returned.")
 	}
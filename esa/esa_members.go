@@ -0,0 +1,113 @@
+package esa
+
+import (
+	"context"
+	"fmt"
+)
+
+// MembersService provides access to the member related functions
+// in the esa API.
+//
+// API docs: https://docs.esa.io/posts/102#6-0-0
+type MembersService service
+
+// Member represents a member of esa team.
+type Member struct {
+	Myself         bool      `json:"myself"`
+	Name           string    `json:"name"`
+	ScreenName     string    `json:"screen_name"`
+	Icon           string    `json:"icon"`
+	Role           string    `json:"role"`
+	PostsCount     int       `json:"posts_count"`
+	JoinedAt       Timestamp `json:"joined_at"`
+	LastAccessedAt Timestamp `json:"last_accessed_at"`
+	Email          string    `json:"email"`
+}
+
+func (m Member) String() string {
+	return Stringify(m)
+}
+
+// MemberList represents a list of members.
+type MemberList struct {
+	Members    []*Member `json:"members"`
+	PrevPage   int       `json:"prev_page"`
+	NextPage   int       `json:"next_page"`
+	TotalCount int       `json:"total_count"`
+	Page       int       `json:"page"`
+	PerPage    int       `json:"per_page"`
+	MaxPerPage int       `json:"max_per_page"`
+}
+
+func (l MemberList) String() string {
+	return Stringify(l)
+}
+
+// MemberListOptions specifies the optional parameters to the
+// MembersService.List method.
+type MemberListOptions struct {
+	// Sort specifies how to sort members.
+	// Possible values are: posts_count, joined, last_accessed. Default: joined
+	Sort string `url:"sort,omitempty"`
+
+	// Order specifies the direction of sort.
+	// Possible values are: asc, desc. Default: desc
+	Order string `url:"order,omitempty"`
+
+	ListOptions
+}
+
+// List lists members of a team.
+//
+// API docs: https://docs.esa.io/posts/102#6-1-0
+func (s *MembersService) List(ctx context.Context, team string, opts *MemberListOptions) (*MemberList, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("teams/%s/members", s.client.teamName(team)), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	l := &MemberList{}
+	resp, err := s.client.Do(ctx, req, l)
+	if err != nil {
+		return nil, resp, err
+	}
+	return l, resp, nil
+}
+
+// ListAll returns an Iterator over all members of a team, starting from the
+// page specified by opts.
+func (s *MembersService) ListAll(team string, opts *MemberListOptions) *Iterator[*Member] {
+	o := MemberListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return newIterator(o.Page, func(ctx context.Context, page int) ([]*Member, int, *Response, error) {
+		o.Page = page
+		l, resp, err := s.List(ctx, team, &o)
+		if err != nil {
+			return nil, 0, resp, err
+		}
+		return l.Members, l.NextPage, resp, nil
+	})
+}
+
+// Delete removes a member from a team by screen name.
+//
+// API docs: https://docs.esa.io/posts/102#6-2-0
+func (s *MembersService) Delete(ctx context.Context, team string, screenName string) (*Response, error) {
+	u := fmt.Sprintf("teams/%s/members/%s", s.client.teamName(team), screenName)
+	req, err := s.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
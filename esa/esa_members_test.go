@@ -0,0 +1,180 @@
+package esa
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMembersService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/members", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"sort":     "posts_count",
+			"order":    "asc",
+			"page":     "2",
+			"per_page": "1",
+		})
+		fmt.Fprint(w, `{
+  "members": [
+    {
+      "myself": true,
+      "name": "Atsuo Fukaya",
+      "screen_name": "fukayatsu",
+      "icon": "https://img.esa.io/uploads/production/users/1/icon/thumb_m_402685a258cf2a33c1d6c13a89adec92.png",
+      "role": "owner",
+      "posts_count": 222,
+      "joined_at": "2014-05-10T11:50:07+09:00",
+      "last_accessed_at": "2019-02-14T19:18:20+09:00",
+      "email": "fukayatsu@esa.io"
+    }
+  ],
+  "prev_page": 1,
+  "next_page": 3,
+  "total_count": 3,
+  "page": 2,
+  "per_page": 1,
+  "max_per_page": 100
+}`)
+	})
+
+	opts := &MemberListOptions{Sort: "posts_count", Order: "asc", ListOptions: ListOptions{Page: 2, PerPage: 1}}
+	list, _, err := client.Members.List(context.Background(), "hoge", opts)
+	if err != nil {
+		t.Errorf("Members.List returned error: %v", err)
+	}
+
+	want := &MemberList{
+		Members: []*Member{
+			{
+				Myself:         true,
+				Name:           "Atsuo Fukaya",
+				ScreenName:     "fukayatsu",
+				Icon:           "https://img.esa.io/uploads/production/users/1/icon/thumb_m_402685a258cf2a33c1d6c13a89adec92.png",
+				Role:           "owner",
+				PostsCount:     222,
+				JoinedAt:       Timestamp{time.Date(2014, 5, 10, 11, 50, 7, 0, jst).Local()},
+				LastAccessedAt: Timestamp{time.Date(2019, 2, 14, 19, 18, 20, 0, jst).Local()},
+				Email:          "fukayatsu@esa.io",
+			},
+		},
+		PrevPage:   1,
+		NextPage:   3,
+		TotalCount: 3,
+		Page:       2,
+		PerPage:    1,
+		MaxPerPage: 100,
+	}
+	if !reflect.DeepEqual(list, want) {
+		t.Errorf("MembersService.List returned %+v, want %+v", list, want)
+	}
+}
+
+func TestMembersService_List_noOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/members", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{})
+		fmt.Fprint(w, `{"members": []}`)
+	})
+
+	_, _, err := client.Members.List(context.Background(), "hoge", nil)
+	if err != nil {
+		t.Errorf("Members.List returned error: %v", err)
+	}
+}
+
+func TestMembersService_List_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/members", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	})
+
+	_, resp, err := client.Members.List(context.Background(), "hoge", nil)
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("MembersService.List returned Reponse, too")
+	}
+}
+
+func TestMembersService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/members/fukayatsu", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testFormValues(t, r, values{})
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	resp, err := client.Members.Delete(context.Background(), "hoge", "fukayatsu")
+	if err != nil {
+		t.Errorf("Members.Delete returned error: %v", err)
+	}
+
+	if resp == nil {
+		t.Error("Members.Delete returned Response.")
+	}
+}
+
+func TestMembersService_Delete_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/members/fukayatsu", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	})
+
+	resp, err := client.Members.Delete(context.Background(), "hoge", "fukayatsu")
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("MembersService.Delete returned Reponse.")
+	}
+}
+
+func TestMembersService_ListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/members", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch page := r.URL.Query().Get("page"); page {
+		case "1":
+			testFormValues(t, r, values{"sort": "last_accessed", "page": "1"})
+			fmt.Fprint(w, `{"members": [{"screen_name": "fukayatsu"}], "next_page": 2}`)
+		case "2":
+			testFormValues(t, r, values{"sort": "last_accessed", "page": "2"})
+			fmt.Fprint(w, `{"members": [{"screen_name": "taea"}], "prev_page": 1}`)
+		default:
+			t.Errorf("Unexpected page %v", page)
+		}
+	})
+
+	it := client.Members.ListAll("hoge", &MemberListOptions{Sort: "last_accessed"})
+	var names []string
+	for it.Next(context.Background()) {
+		names = append(names, it.Value().ScreenName)
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Members.ListAll returned error: %v", err)
+	}
+	if want := []string{"fukayatsu", "taea"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("MembersService.ListAll returned %v, want %v", names, want)
+	}
+}
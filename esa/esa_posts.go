@@ -0,0 +1,221 @@
+package esa
+
+import (
+	"context"
+	"fmt"
+)
+
+// PostsService provides access to the post related functions
+// in the esa API.
+//
+// API docs: https://docs.esa.io/posts/102#7-0-0
+type PostsService service
+
+// Author represents a user who wrote or updated a post or a comment.
+type Author struct {
+	Myself     bool   `json:"myself"`
+	Name       string `json:"name"`
+	ScreenName string `json:"screen_name"`
+	Icon       string `json:"icon"`
+}
+
+func (a Author) String() string {
+	return Stringify(a)
+}
+
+// Post represents a post of esa team.
+type Post struct {
+	Number          int       `json:"number"`
+	Name            string    `json:"name"`
+	FullName        string    `json:"full_name"`
+	WIP             bool      `json:"wip"`
+	BodyMD          string    `json:"body_md"`
+	BodyHTML        string    `json:"body_html"`
+	CreatedAt       Timestamp `json:"created_at"`
+	Message         string    `json:"message"`
+	URL             string    `json:"url"`
+	UpdatedAt       Timestamp `json:"updated_at"`
+	Tags            []string  `json:"tags"`
+	Category        string    `json:"category"`
+	RevisionNumber  int       `json:"revision_number"`
+	CreatedBy       *Author   `json:"created_by"`
+	UpdatedBy       *Author   `json:"updated_by"`
+	Kind            string    `json:"kind"`
+	CommentsCount   int       `json:"comments_count"`
+	TasksCount      int       `json:"tasks_count"`
+	DoneTasksCount  int       `json:"done_tasks_count"`
+	StargazersCount int       `json:"stargazers_count"`
+	WatchersCount   int       `json:"watchers_count"`
+	Star            bool      `json:"star"`
+	Watch           bool      `json:"watch"`
+}
+
+func (p Post) String() string {
+	return Stringify(p)
+}
+
+// PostList represents a list of posts.
+type PostList struct {
+	Posts      []*Post `json:"posts"`
+	PrevPage   int     `json:"prev_page"`
+	NextPage   int     `json:"next_page"`
+	TotalCount int     `json:"total_count"`
+	Page       int     `json:"page"`
+	PerPage    int     `json:"per_page"`
+	MaxPerPage int     `json:"max_per_page"`
+}
+
+func (l PostList) String() string {
+	return Stringify(l)
+}
+
+// PostRequest represents a post to be created or updated.
+// Fields left as zero values are not sent.
+type PostRequest struct {
+	Name     string   `json:"name,omitempty"`
+	BodyMD   string   `json:"body_md,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Category string   `json:"category,omitempty"`
+	WIP      *bool    `json:"wip,omitempty"`
+	Message  string   `json:"message,omitempty"`
+}
+
+func (r PostRequest) String() string {
+	return Stringify(r)
+}
+
+// postRequest is the request body of creating and updating a post.
+type postRequest struct {
+	Post *PostRequest `json:"post"`
+}
+
+// PostListOptions specifies the optional parameters to the
+// PostsService.List method.
+type PostListOptions struct {
+	// Q filters posts by a search query.
+	// ref. https://docs.esa.io/posts/104
+	Q string `url:"q,omitempty"`
+
+	// Include embeds related resources into each post.
+	// Possible values are: comments, comments.stargazers, stargazers
+	Include string `url:"include,omitempty"`
+
+	// Sort specifies how to sort posts.
+	// Possible values are: updated, created, number, stars, watches, comments, best_match. Default: updated
+	Sort string `url:"sort,omitempty"`
+
+	// Order specifies the direction of sort.
+	// Possible values are: asc, desc. Default: desc
+	Order string `url:"order,omitempty"`
+
+	ListOptions
+}
+
+// List lists posts of a team.
+//
+// API docs: https://docs.esa.io/posts/102#7-1-0
+func (s *PostsService) List(ctx context.Context, team string, opts *PostListOptions) (*PostList, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("teams/%s/posts", s.client.teamName(team)), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := &PostList{}
+	resp, err := s.client.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+	return list, resp, nil
+}
+
+// ListAll returns an Iterator over all posts of a team matching opts,
+// starting from the page specified by opts.
+func (s *PostsService) ListAll(team string, opts *PostListOptions) *Iterator[*Post] {
+	o := PostListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return newIterator(o.Page, func(ctx context.Context, page int) ([]*Post, int, *Response, error) {
+		o.Page = page
+		l, resp, err := s.List(ctx, team, &o)
+		if err != nil {
+			return nil, 0, resp, err
+		}
+		return l.Posts, l.NextPage, resp, nil
+	})
+}
+
+// Get fetches a post by number.
+//
+// API docs: https://docs.esa.io/posts/102#7-2-0
+func (s *PostsService) Get(ctx context.Context, team string, number int) (*Post, *Response, error) {
+	u := fmt.Sprintf("teams/%s/posts/%d", s.client.teamName(team), number)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p := &Post{}
+	resp, err := s.client.Do(ctx, req, p)
+	if err != nil {
+		return nil, resp, err
+	}
+	return p, resp, nil
+}
+
+// Create creates a new post.
+//
+// API docs: https://docs.esa.io/posts/102#7-3-0
+func (s *PostsService) Create(ctx context.Context, team string, post *PostRequest) (*Post, *Response, error) {
+	u := fmt.Sprintf("teams/%s/posts", s.client.teamName(team))
+	req, err := s.client.NewRequest("POST", u, &postRequest{Post: post})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p := &Post{}
+	resp, err := s.client.Do(ctx, req, p)
+	if err != nil {
+		return nil, resp, err
+	}
+	return p, resp, nil
+}
+
+// Update updates a post by number.
+//
+// API docs: https://docs.esa.io/posts/102#7-4-0
+func (s *PostsService) Update(ctx context.Context, team string, number int, post *PostRequest) (*Post, *Response, error) {
+	u := fmt.Sprintf("teams/%s/posts/%d", s.client.teamName(team), number)
+	req, err := s.client.NewRequest("PATCH", u, &postRequest{Post: post})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p := &Post{}
+	resp, err := s.client.Do(ctx, req, p)
+	if err != nil {
+		return nil, resp, err
+	}
+	return p, resp, nil
+}
+
+// Delete deletes a post by number.
+//
+// API docs: https://docs.esa.io/posts/102#7-5-0
+func (s *PostsService) Delete(ctx context.Context, team string, number int) (*Response, error) {
+	u := fmt.Sprintf("teams/%s/posts/%d", s.client.teamName(team), number)
+	req, err := s.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
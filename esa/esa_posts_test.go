@@ -0,0 +1,349 @@
+package esa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const postJSON = `{
+  "number": 1,
+  "name": "hi!",
+  "full_name": "日報/2015/05/09/hi! #api #dev",
+  "wip": true,
+  "body_md": "# Getting Started",
+  "body_html": "<h1 id=\"1-0-0\" name=\"1-0-0\">\n<a class=\"anchor\" href=\"#1-0-0\"><i class=\"fa fa-link\"></i><span class=\"hidden\" data-text=\"Getting Started\"> &gt; Getting Started</span></a>Getting Started</h1>\n",
+  "created_at": "2015-05-09T11:54:50+09:00",
+  "message": "Add Getting Started section",
+  "url": "https://docs.esa.io/posts/1",
+  "updated_at": "2015-05-09T11:54:51+09:00",
+  "tags": [
+    "api",
+    "dev"
+  ],
+  "category": "日報/2015/05/09",
+  "revision_number": 1,
+  "created_by": {
+    "myself": true,
+    "name": "Atsuo Fukaya",
+    "screen_name": "fukayatsu",
+    "icon": "http://img.esa.io/uploads/production/users/1/icon/thumb_s_402685a258cf2a33c1d6c13a89adec92.png"
+  },
+  "updated_by": {
+    "myself": true,
+    "name": "Atsuo Fukaya",
+    "screen_name": "fukayatsu",
+    "icon": "http://img.esa.io/uploads/production/users/1/icon/thumb_s_402685a258cf2a33c1d6c13a89adec92.png"
+  },
+  "kind": "flow",
+  "comments_count": 1,
+  "tasks_count": 1,
+  "done_tasks_count": 1,
+  "stargazers_count": 1,
+  "watchers_count": 1,
+  "star": true,
+  "watch": true
+}`
+
+var wantPost = &Post{
+	Number:    1,
+	Name:      "hi!",
+	FullName:  "日報/2015/05/09/hi! #api #dev",
+	WIP:       true,
+	BodyMD:    "# Getting Started",
+	BodyHTML:  "<h1 id=\"1-0-0\" name=\"1-0-0\">\n<a class=\"anchor\" href=\"#1-0-0\"><i class=\"fa fa-link\"></i><span class=\"hidden\" data-text=\"Getting Started\"> &gt; Getting Started</span></a>Getting Started</h1>\n",
+	CreatedAt: Timestamp{time.Date(2015, 5, 9, 11, 54, 50, 0, jst).Local()},
+	Message:   "Add Getting Started section",
+	URL:       "https://docs.esa.io/posts/1",
+	UpdatedAt: Timestamp{time.Date(2015, 5, 9, 11, 54, 51, 0, jst).Local()},
+	Tags:      []string{"api", "dev"},
+	Category:  "日報/2015/05/09",
+
+	RevisionNumber: 1,
+	CreatedBy: &Author{
+		Myself:     true,
+		Name:       "Atsuo Fukaya",
+		ScreenName: "fukayatsu",
+		Icon:       "http://img.esa.io/uploads/production/users/1/icon/thumb_s_402685a258cf2a33c1d6c13a89adec92.png",
+	},
+	UpdatedBy: &Author{
+		Myself:     true,
+		Name:       "Atsuo Fukaya",
+		ScreenName: "fukayatsu",
+		Icon:       "http://img.esa.io/uploads/production/users/1/icon/thumb_s_402685a258cf2a33c1d6c13a89adec92.png",
+	},
+	Kind:            "flow",
+	CommentsCount:   1,
+	TasksCount:      1,
+	DoneTasksCount:  1,
+	StargazersCount: 1,
+	WatchersCount:   1,
+	Star:            true,
+	Watch:           true,
+}
+
+func TestPostsService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{})
+		fmt.Fprintf(w, `{
+  "posts": [%s],
+  "prev_page": null,
+  "next_page": null,
+  "total_count": 1,
+  "page": 1,
+  "per_page": 20,
+  "max_per_page": 100
+}`, postJSON)
+	})
+
+	list, _, err := client.Posts.List(context.Background(), "hoge", nil)
+	if err != nil {
+		t.Errorf("Posts.List returned error: %v", err)
+	}
+
+	want := &PostList{
+		Posts:      []*Post{wantPost},
+		PrevPage:   0,
+		NextPage:   0,
+		TotalCount: 1,
+		Page:       1,
+		PerPage:    20,
+		MaxPerPage: 100,
+	}
+	if !reflect.DeepEqual(list, want) {
+		t.Errorf("PostsService.List returned %+v, want %+v", list, want)
+	}
+}
+
+func TestPostsService_List_withOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"q":        "in:日報 wip:false",
+			"include":  "comments",
+			"sort":     "created",
+			"order":    "asc",
+			"page":     "3",
+			"per_page": "50",
+		})
+		fmt.Fprint(w, `{"posts": []}`)
+	})
+
+	opts := &PostListOptions{
+		Q:           "in:日報 wip:false",
+		Include:     "comments",
+		Sort:        "created",
+		Order:       "asc",
+		ListOptions: ListOptions{Page: 3, PerPage: 50},
+	}
+	_, _, err := client.Posts.List(context.Background(), "hoge", opts)
+	if err != nil {
+		t.Errorf("Posts.List returned error: %v", err)
+	}
+}
+
+func TestPostsService_List_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	})
+
+	_, resp, err := client.Posts.List(context.Background(), "hoge", nil)
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("PostsService.List returned Reponse, too")
+	}
+}
+
+func TestPostsService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{})
+		fmt.Fprint(w, postJSON)
+	})
+
+	post, _, err := client.Posts.Get(context.Background(), "hoge", 1)
+	if err != nil {
+		t.Errorf("Posts.Get returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(post, wantPost) {
+		t.Errorf("PostsService.Get returned %+v, want %+v", post, wantPost)
+	}
+}
+
+func TestPostsService_Get_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/1", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	_, resp, err := client.Posts.Get(context.Background(), "hoge", 1)
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("PostsService.Get returned Reponse, too")
+	}
+}
+
+func TestPostsService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	input := &PostRequest{
+		Name:     "hi!",
+		BodyMD:   "# Getting Started",
+		Tags:     []string{"api", "dev"},
+		Category: "日報/2015/05/09",
+		WIP:      Bool(false),
+		Message:  "Add Getting Started section",
+	}
+
+	mux.HandleFunc("/v1/teams/hoge/posts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		v := new(postRequest)
+		json.NewDecoder(r.Body).Decode(v)
+		if !reflect.DeepEqual(v.Post, input) {
+			t.Errorf("Request body = %+v, want %+v", v.Post, input)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, postJSON)
+	})
+
+	post, _, err := client.Posts.Create(context.Background(), "hoge", input)
+	if err != nil {
+		t.Errorf("Posts.Create returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(post, wantPost) {
+		t.Errorf("PostsService.Create returned %+v, want %+v", post, wantPost)
+	}
+}
+
+func TestPostsService_Create_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	})
+
+	_, resp, err := client.Posts.Create(context.Background(), "hoge", &PostRequest{})
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("PostsService.Create returned Reponse, too")
+	}
+}
+
+func TestPostsService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	input := &PostRequest{BodyMD: "# Getting Started"}
+
+	mux.HandleFunc("/v1/teams/hoge/posts/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		v := map[string]map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&v)
+		want := map[string]map[string]interface{}{
+			"post": {"body_md": "# Getting Started"},
+		}
+		if !reflect.DeepEqual(v, want) {
+			t.Errorf("Request body = %+v, want %+v", v, want)
+		}
+
+		fmt.Fprint(w, postJSON)
+	})
+
+	post, _, err := client.Posts.Update(context.Background(), "hoge", 1, input)
+	if err != nil {
+		t.Errorf("Posts.Update returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(post, wantPost) {
+		t.Errorf("PostsService.Update returned %+v, want %+v", post, wantPost)
+	}
+}
+
+func TestPostsService_Update_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/1", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	})
+
+	_, resp, err := client.Posts.Update(context.Background(), "hoge", 1, &PostRequest{})
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("PostsService.Update returned Reponse, too")
+	}
+}
+
+func TestPostsService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testFormValues(t, r, values{})
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	resp, err := client.Posts.Delete(context.Background(), "hoge", 1)
+	if err != nil {
+		t.Errorf("Posts.Delete returned error: %v", err)
+	}
+
+	if resp == nil {
+		t.Error("Posts.Delete returned Response.")
+	}
+}
+
+func TestPostsService_Delete_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/1", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	})
+
+	resp, err := client.Posts.Delete(context.Background(), "hoge", 1)
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("PostsService.Delete returned Reponse.")
+	}
+}
@@ -0,0 +1,179 @@
+package esa
+
+import (
+	"context"
+	"fmt"
+)
+
+// StarsService provides access to the star related functions
+// in the esa API.
+//
+// API docs: https://docs.esa.io/posts/102#9-0-0
+type StarsService service
+
+// Stargazer represents a user who starred a post or a comment.
+type Stargazer struct {
+	CreatedAt Timestamp `json:"created_at"`
+	Body      string    `json:"body"`
+	User      *Author   `json:"user"`
+}
+
+func (s Stargazer) String() string {
+	return Stringify(s)
+}
+
+// StargazerList represents a list of stargazers.
+type StargazerList struct {
+	Stargazers []*Stargazer `json:"stargazers"`
+	PrevPage   int          `json:"prev_page"`
+	NextPage   int          `json:"next_page"`
+	TotalCount int          `json:"total_count"`
+	Page       int          `json:"page"`
+	PerPage    int          `json:"per_page"`
+	MaxPerPage int          `json:"max_per_page"`
+}
+
+func (l StargazerList) String() string {
+	return Stringify(l)
+}
+
+// StarRequest represents a star to be added.
+type StarRequest struct {
+	// Body is an optional message quoted with the star.
+	Body string `json:"body,omitempty"`
+}
+
+func (r StarRequest) String() string {
+	return Stringify(r)
+}
+
+// ListPostStargazers lists users who starred a post.
+//
+// API docs: https://docs.esa.io/posts/102#9-1-0
+func (s *StarsService) ListPostStargazers(ctx context.Context, team string, number int, opts *ListOptions) (*StargazerList, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("teams/%s/posts/%d/stargazers", s.client.teamName(team), number), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.listStargazers(ctx, u)
+}
+
+// ListAllPostStargazers returns an Iterator over all users who starred a
+// post, starting from the page specified by opts.
+func (s *StarsService) ListAllPostStargazers(team string, number int, opts *ListOptions) *Iterator[*Stargazer] {
+	o := ListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return newIterator(o.Page, func(ctx context.Context, page int) ([]*Stargazer, int, *Response, error) {
+		o.Page = page
+		l, resp, err := s.ListPostStargazers(ctx, team, number, &o)
+		if err != nil {
+			return nil, 0, resp, err
+		}
+		return l.Stargazers, l.NextPage, resp, nil
+	})
+}
+
+// StarPost stars a post.
+//
+// API docs: https://docs.esa.io/posts/102#9-2-0
+func (s *StarsService) StarPost(ctx context.Context, team string, number int, star *StarRequest) (*Response, error) {
+	u := fmt.Sprintf("teams/%s/posts/%d/star", s.client.teamName(team), number)
+	return s.star(ctx, u, star)
+}
+
+// UnstarPost removes the star from a post.
+//
+// API docs: https://docs.esa.io/posts/102#9-3-0
+func (s *StarsService) UnstarPost(ctx context.Context, team string, number int) (*Response, error) {
+	u := fmt.Sprintf("teams/%s/posts/%d/star", s.client.teamName(team), number)
+	return s.unstar(ctx, u)
+}
+
+// ListCommentStargazers lists users who starred a comment.
+//
+// API docs: https://docs.esa.io/posts/102#9-4-0
+func (s *StarsService) ListCommentStargazers(ctx context.Context, team string, id int, opts *ListOptions) (*StargazerList, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("teams/%s/comments/%d/stargazers", s.client.teamName(team), id), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.listStargazers(ctx, u)
+}
+
+// ListAllCommentStargazers returns an Iterator over all users who starred a
+// comment, starting from the page specified by opts.
+func (s *StarsService) ListAllCommentStargazers(team string, id int, opts *ListOptions) *Iterator[*Stargazer] {
+	o := ListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return newIterator(o.Page, func(ctx context.Context, page int) ([]*Stargazer, int, *Response, error) {
+		o.Page = page
+		l, resp, err := s.ListCommentStargazers(ctx, team, id, &o)
+		if err != nil {
+			return nil, 0, resp, err
+		}
+		return l.Stargazers, l.NextPage, resp, nil
+	})
+}
+
+// StarComment stars a comment.
+//
+// API docs: https://docs.esa.io/posts/102#9-5-0
+func (s *StarsService) StarComment(ctx context.Context, team string, id int, star *StarRequest) (*Response, error) {
+	u := fmt.Sprintf("teams/%s/comments/%d/star", s.client.teamName(team), id)
+	return s.star(ctx, u, star)
+}
+
+// UnstarComment removes the star from a comment.
+//
+// API docs: https://docs.esa.io/posts/102#9-6-0
+func (s *StarsService) UnstarComment(ctx context.Context, team string, id int) (*Response, error) {
+	u := fmt.Sprintf("teams/%s/comments/%d/star", s.client.teamName(team), id)
+	return s.unstar(ctx, u)
+}
+
+func (s *StarsService) listStargazers(ctx context.Context, u string) (*StargazerList, *Response, error) {
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	l := &StargazerList{}
+	resp, err := s.client.Do(ctx, req, l)
+	if err != nil {
+		return nil, resp, err
+	}
+	return l, resp, nil
+}
+
+func (s *StarsService) star(ctx context.Context, u string, star *StarRequest) (*Response, error) {
+	if star == nil {
+		star = &StarRequest{}
+	}
+	req, err := s.client.NewRequest("POST", u, star)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+func (s *StarsService) unstar(ctx context.Context, u string) (*Response, error) {
+	req, err := s.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,301 @@
+package esa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStarsService_ListPostStargazers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/2312/stargazers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"page": "2", "per_page": "1"})
+		fmt.Fprint(w, `{
+  "stargazers": [
+    {
+      "created_at": "2016-05-05T11:40:54+09:00",
+      "body": "foo",
+      "user": {
+        "name": "Atsuo Fukaya",
+        "screen_name": "fukayatsu",
+        "icon": "https://img.esa.io/uploads/production/users/1/icon/thumb_s_402685a258cf2a33c1d6c13a89adec92.png"
+      }
+    }
+  ],
+  "prev_page": 1,
+  "next_page": null,
+  "total_count": 2,
+  "page": 2,
+  "per_page": 1,
+  "max_per_page": 100
+}`)
+	})
+
+	opts := &ListOptions{Page: 2, PerPage: 1}
+	list, _, err := client.Stars.ListPostStargazers(context.Background(), "hoge", 2312, opts)
+	if err != nil {
+		t.Errorf("Stars.ListPostStargazers returned error: %v", err)
+	}
+
+	want := &StargazerList{
+		Stargazers: []*Stargazer{
+			{
+				CreatedAt: Timestamp{time.Date(2016, 5, 5, 11, 40, 54, 0, jst).Local()},
+				Body:      "foo",
+				User: &Author{
+					Name:       "Atsuo Fukaya",
+					ScreenName: "fukayatsu",
+					Icon:       "https://img.esa.io/uploads/production/users/1/icon/thumb_s_402685a258cf2a33c1d6c13a89adec92.png",
+				},
+			},
+		},
+		PrevPage:   1,
+		TotalCount: 2,
+		Page:       2,
+		PerPage:    1,
+		MaxPerPage: 100,
+	}
+	if !reflect.DeepEqual(list, want) {
+		t.Errorf("StarsService.ListPostStargazers returned %+v, want %+v", list, want)
+	}
+}
+
+func TestStarsService_ListPostStargazers_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/2312/stargazers", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	_, resp, err := client.Stars.ListPostStargazers(context.Background(), "hoge", 2312, nil)
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("StarsService.ListPostStargazers returned Reponse, too")
+	}
+}
+
+func TestStarsService_ListAllPostStargazers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pages := map[string]string{
+		"1": `{"stargazers": [{"body": "foo", "user": {"screen_name": "fukayatsu"}}], "next_page": 2}`,
+		"2": `{"stargazers": [{"body": "bar", "user": {"screen_name": "jiroc"}}], "prev_page": 1, "next_page": null}`,
+	}
+	mux.HandleFunc("/v1/teams/hoge/posts/2312/stargazers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, pages[r.URL.Query().Get("page")])
+	})
+
+	stargazers, err := client.Stars.ListAllPostStargazers("hoge", 2312, nil).Collect(context.Background())
+	if err != nil {
+		t.Errorf("Stars.ListAllPostStargazers returned error: %v", err)
+	}
+
+	want := []*Stargazer{
+		{Body: "foo", User: &Author{ScreenName: "fukayatsu"}},
+		{Body: "bar", User: &Author{ScreenName: "jiroc"}},
+	}
+	if !reflect.DeepEqual(stargazers, want) {
+		t.Errorf("StarsService.ListAllPostStargazers returned %+v, want %+v", stargazers, want)
+	}
+}
+
+func TestStarsService_StarPost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	input := &StarRequest{Body: "foo"}
+
+	mux.HandleFunc("/v1/teams/hoge/posts/2312/star", func(w http.ResponseWriter, r *http.Request) {
+		v := new(StarRequest)
+		json.NewDecoder(r.Body).Decode(v)
+
+		testMethod(t, r, "POST")
+		if !reflect.DeepEqual(v, input) {
+			t.Errorf("Request body = %+v, want %+v", v, input)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Stars.StarPost(context.Background(), "hoge", 2312, input)
+	if err != nil {
+		t.Errorf("Stars.StarPost returned error: %v", err)
+	}
+}
+
+func TestStarsService_StarPost_withoutBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/2312/star", func(w http.ResponseWriter, r *http.Request) {
+		v := make(map[string]interface{})
+		json.NewDecoder(r.Body).Decode(&v)
+
+		testMethod(t, r, "POST")
+		if len(v) != 0 {
+			t.Errorf("Request body = %+v, want empty", v)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Stars.StarPost(context.Background(), "hoge", 2312, nil)
+	if err != nil {
+		t.Errorf("Stars.StarPost returned error: %v", err)
+	}
+}
+
+func TestStarsService_UnstarPost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/2312/star", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Stars.UnstarPost(context.Background(), "hoge", 2312)
+	if err != nil {
+		t.Errorf("Stars.UnstarPost returned error: %v", err)
+	}
+}
+
+func TestStarsService_UnstarPost_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/2312/star", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	resp, err := client.Stars.UnstarPost(context.Background(), "hoge", 2312)
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("StarsService.UnstarPost returned Reponse, too")
+	}
+}
+
+func TestStarsService_ListCommentStargazers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/comments/13/stargazers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{})
+		fmt.Fprint(w, `{
+  "stargazers": [
+    {
+      "created_at": "2016-05-05T11:40:54+09:00",
+      "body": null,
+      "user": {
+        "name": "Atsuo Fukaya",
+        "screen_name": "fukayatsu",
+        "icon": "https://img.esa.io/uploads/production/users/1/icon/thumb_s_402685a258cf2a33c1d6c13a89adec92.png"
+      }
+    }
+  ],
+  "prev_page": null,
+  "next_page": null,
+  "total_count": 1,
+  "page": 1,
+  "per_page": 20,
+  "max_per_page": 100
+}`)
+	})
+
+	list, _, err := client.Stars.ListCommentStargazers(context.Background(), "hoge", 13, nil)
+	if err != nil {
+		t.Errorf("Stars.ListCommentStargazers returned error: %v", err)
+	}
+
+	want := &StargazerList{
+		Stargazers: []*Stargazer{
+			{
+				CreatedAt: Timestamp{time.Date(2016, 5, 5, 11, 40, 54, 0, jst).Local()},
+				User: &Author{
+					Name:       "Atsuo Fukaya",
+					ScreenName: "fukayatsu",
+					Icon:       "https://img.esa.io/uploads/production/users/1/icon/thumb_s_402685a258cf2a33c1d6c13a89adec92.png",
+				},
+			},
+		},
+		TotalCount: 1,
+		Page:       1,
+		PerPage:    20,
+		MaxPerPage: 100,
+	}
+	if !reflect.DeepEqual(list, want) {
+		t.Errorf("StarsService.ListCommentStargazers returned %+v, want %+v", list, want)
+	}
+}
+
+func TestStarsService_ListAllCommentStargazers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pages := map[string]string{
+		"1": `{"stargazers": [{"body": "foo", "user": {"screen_name": "fukayatsu"}}], "next_page": 2}`,
+		"2": `{"stargazers": [{"body": "bar", "user": {"screen_name": "jiroc"}}], "prev_page": 1, "next_page": null}`,
+	}
+	mux.HandleFunc("/v1/teams/hoge/comments/13/stargazers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, pages[r.URL.Query().Get("page")])
+	})
+
+	stargazers, err := client.Stars.ListAllCommentStargazers("hoge", 13, nil).Collect(context.Background())
+	if err != nil {
+		t.Errorf("Stars.ListAllCommentStargazers returned error: %v", err)
+	}
+
+	want := []*Stargazer{
+		{Body: "foo", User: &Author{ScreenName: "fukayatsu"}},
+		{Body: "bar", User: &Author{ScreenName: "jiroc"}},
+	}
+	if !reflect.DeepEqual(stargazers, want) {
+		t.Errorf("StarsService.ListAllCommentStargazers returned %+v, want %+v", stargazers, want)
+	}
+}
+
+func TestStarsService_StarComment(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/comments/13/star", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Stars.StarComment(context.Background(), "hoge", 13, nil)
+	if err != nil {
+		t.Errorf("Stars.StarComment returned error: %v", err)
+	}
+}
+
+func TestStarsService_UnstarComment(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/comments/13/star", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Stars.UnstarComment(context.Background(), "hoge", 13)
+	if err != nil {
+		t.Errorf("Stars.UnstarComment returned error: %v", err)
+	}
+}
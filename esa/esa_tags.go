@@ -0,0 +1,75 @@
+package esa
+
+import (
+	"context"
+	"fmt"
+)
+
+// TagsService provides access to the tag related functions
+// in the esa API.
+type TagsService service
+
+// Tag represents a tag used in a team.
+type Tag struct {
+	Name       string `json:"name"`
+	PostsCount int    `json:"posts_count"`
+}
+
+func (t Tag) String() string {
+	return Stringify(t)
+}
+
+// TagList represents a list of tags.
+type TagList struct {
+	Tags       []*Tag `json:"tags"`
+	PrevPage   int    `json:"prev_page"`
+	NextPage   int    `json:"next_page"`
+	TotalCount int    `json:"total_count"`
+	Page       int    `json:"page"`
+	PerPage    int    `json:"per_page"`
+	MaxPerPage int    `json:"max_per_page"`
+}
+
+func (l TagList) String() string {
+	return Stringify(l)
+}
+
+// List lists tags of a team with the number of posts tagged.
+//
+// The tags endpoint has no section in the API docs at
+// https://docs.esa.io/posts/102.
+func (s *TagsService) List(ctx context.Context, team string, opts *ListOptions) (*TagList, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("teams/%s/tags", s.client.teamName(team)), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	l := &TagList{}
+	resp, err := s.client.Do(ctx, req, l)
+	if err != nil {
+		return nil, resp, err
+	}
+	return l, resp, nil
+}
+
+// ListAll returns an Iterator over all tags of a team, starting from the
+// page specified by opts.
+func (s *TagsService) ListAll(team string, opts *ListOptions) *Iterator[*Tag] {
+	o := ListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return newIterator(o.Page, func(ctx context.Context, page int) ([]*Tag, int, *Response, error) {
+		o.Page = page
+		l, resp, err := s.List(ctx, team, &o)
+		if err != nil {
+			return nil, 0, resp, err
+		}
+		return l.Tags, l.NextPage, resp, nil
+	})
+}
@@ -0,0 +1,103 @@
+package esa
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestTagsService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/tags", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"page": "1", "per_page": "2"})
+		fmt.Fprint(w, `{
+  "tags": [
+    {
+      "name": "api",
+      "posts_count": 3
+    },
+    {
+      "name": "esa",
+      "posts_count": 1
+    }
+  ],
+  "prev_page": null,
+  "next_page": 2,
+  "total_count": 3,
+  "page": 1,
+  "per_page": 2,
+  "max_per_page": 100
+}`)
+	})
+
+	opts := &ListOptions{Page: 1, PerPage: 2}
+	list, _, err := client.Tags.List(context.Background(), "hoge", opts)
+	if err != nil {
+		t.Errorf("Tags.List returned error: %v", err)
+	}
+
+	want := &TagList{
+		Tags: []*Tag{
+			{Name: "api", PostsCount: 3},
+			{Name: "esa", PostsCount: 1},
+		},
+		NextPage:   2,
+		TotalCount: 3,
+		Page:       1,
+		PerPage:    2,
+		MaxPerPage: 100,
+	}
+	if !reflect.DeepEqual(list, want) {
+		t.Errorf("TagsService.List returned %+v, want %+v", list, want)
+	}
+}
+
+func TestTagsService_List_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/tags", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	_, resp, err := client.Tags.List(context.Background(), "hoge", nil)
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("TagsService.List returned Reponse, too")
+	}
+}
+
+func TestTagsService_ListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pages := map[string]string{
+		"1": `{"tags": [{"name": "api", "posts_count": 3}], "next_page": 2}`,
+		"2": `{"tags": [{"name": "esa", "posts_count": 1}], "prev_page": 1, "next_page": null}`,
+	}
+	mux.HandleFunc("/v1/teams/hoge/tags", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, pages[r.URL.Query().Get("page")])
+	})
+
+	tags, err := client.Tags.ListAll("hoge", nil).Collect(context.Background())
+	if err != nil {
+		t.Errorf("Tags.ListAll returned error: %v", err)
+	}
+
+	want := []*Tag{
+		{Name: "api", PostsCount: 3},
+		{Name: "esa", PostsCount: 1},
+	}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("TagsService.ListAll returned %+v, want %+v", tags, want)
+	}
+}
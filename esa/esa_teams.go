@@ -59,8 +59,12 @@ func (s TeamStats) String() string {
 // List lists all teams
 //
 // API docs: https://docs.esa.io/posts/102#4-1-0
-func (s *TeamsService) List(ctx context.Context) (*TeamList, *Response, error) {
-	req, err := s.client.NewRequest("GET", "teams", nil)
+func (s *TeamsService) List(ctx context.Context, opts *ListOptions) (*TeamList, *Response, error) {
+	u, err := addOptions("teams", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -73,11 +77,28 @@ func (s *TeamsService) List(ctx context.Context) (*TeamList, *Response, error) {
 	return list, resp, nil
 }
 
+// ListAll returns an Iterator over all teams, starting from the page
+// specified by opts.
+func (s *TeamsService) ListAll(opts *ListOptions) *Iterator[*Team] {
+	o := ListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return newIterator(o.Page, func(ctx context.Context, page int) ([]*Team, int, *Response, error) {
+		o.Page = page
+		l, resp, err := s.List(ctx, &o)
+		if err != nil {
+			return nil, 0, resp, err
+		}
+		return l.Teams, l.NextPage, resp, nil
+	})
+}
+
 // Get fetches a team by name.
 //
 // API docs: https://docs.esa.io/posts/102#4-2-0
 func (s *TeamsService) Get(ctx context.Context, team string) (*Team, *Response, error) {
-	u := fmt.Sprintf("teams/%s", team)
+	u := fmt.Sprintf("teams/%s", s.client.teamName(team))
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
@@ -95,7 +116,7 @@ func (s *TeamsService) Get(ctx context.Context, team string) (*Team, *Response,
 //
 // API docs: https://docs.esa.io/posts/102#5-1-0
 func (s *TeamsService) GetStats(ctx context.Context, team string) (*TeamStats, *Response, error) {
-	u := fmt.Sprintf("teams/%s/stats", team)
+	u := fmt.Sprintf("teams/%s/stats", s.client.teamName(team))
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
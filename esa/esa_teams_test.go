@@ -34,7 +34,7 @@ func TestTeamsService_ListAll(t *testing.T) {
 }`)
 	})
 
-	list, _, err := client.Teams.List(context.Background())
+	list, _, err := client.Teams.List(context.Background(), nil)
 	if err != nil {
 		t.Errorf("Teams.List returned error: %v", err)
 	}
@@ -61,6 +61,27 @@ func TestTeamsService_ListAll(t *testing.T) {
 	}
 }
 
+func TestTeamsService_List_withOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"page": "2", "per_page": "1"})
+		fmt.Fprint(w, `{"teams": [], "prev_page": 1, "page": 2, "per_page": 1}`)
+	})
+
+	list, _, err := client.Teams.List(context.Background(), &ListOptions{Page: 2, PerPage: 1})
+	if err != nil {
+		t.Errorf("Teams.List returned error: %v", err)
+	}
+
+	want := &TeamList{Teams: []*Team{}, PrevPage: 1, Page: 2, PerPage: 1}
+	if !reflect.DeepEqual(list, want) {
+		t.Errorf("TeamsService.List returned %+v, want %+v", list, want)
+	}
+}
+
 func TestTeamsService_ListAll_ErrorStatus(t *testing.T) {
 	setup()
 	defer teardown()
@@ -69,7 +90,7 @@ func TestTeamsService_ListAll_ErrorStatus(t *testing.T) {
 		http.Error(w, "Forbidden", http.StatusForbidden)
 	})
 
-	_, resp, err := client.Teams.List(context.Background())
+	_, resp, err := client.Teams.List(context.Background(), nil)
 	if err == nil {
 		t.Error("Expected error to be returned.")
 	}
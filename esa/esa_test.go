@@ -392,12 +392,12 @@ func TestCheckResponse_noBody(t *testing.T) {
 		t.Errorf("Expected error response.")
 	}
 
-	want := &ErrorResponse{
-		Response: res,
-		err:      errors.New("Not JSON"),
+	if err.Response != res {
+		t.Errorf("Error.Response = %#v, want %#v", err.Response, res)
 	}
-	if !reflect.DeepEqual(err, want) {
-		t.Errorf("Error = %#v, want %#v", err, want)
+	var jsonErr *json.SyntaxError
+	if !errors.As(err, &jsonErr) {
+		t.Errorf("errors.As(%v, *json.SyntaxError) = false, want true", err)
 	}
 }
 
@@ -408,3 +408,121 @@ func TestErrorResponse_Error(t *testing.T) {
 		t.Errorf("Expected non-empty ErrorResponse.Error()")
 	}
 }
+
+func TestErrorResponse_Is(t *testing.T) {
+	sentinels := []error{ErrValidation, ErrUnauthorized, ErrForbidden, ErrNotFound, ErrConflict, ErrRateLimited, ErrServer}
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusBadRequest, ErrValidation},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusUnprocessableEntity, ErrValidation},
+		{http.StatusInternalServerError, ErrServer},
+		{http.StatusServiceUnavailable, ErrServer},
+		{http.StatusTeapot, nil},
+	}
+
+	for _, tt := range tests {
+		res := &http.Response{
+			Request:    &http.Request{},
+			StatusCode: tt.status,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"message":"m"}`)),
+		}
+		err := CheckResponse(res)
+		for _, target := range sentinels {
+			if got, want := errors.Is(err, target), target == tt.want; got != want {
+				t.Errorf("errors.Is(%d, %v) = %v, want %v", tt.status, target, got, want)
+			}
+		}
+		var e *ErrorResponse
+		if !errors.As(err, &e) {
+			t.Errorf("errors.As(%d, *ErrorResponse) = false, want true", tt.status)
+		}
+	}
+}
+
+func TestErrorResponse_Unwrap(t *testing.T) {
+	res := &http.Response{
+		Request:    &http.Request{},
+		StatusCode: http.StatusBadGateway,
+		Body:       ioutil.NopCloser(strings.NewReader("<html>Bad Gateway</html>")),
+	}
+	err := CheckResponse(res)
+
+	var jsonErr *json.SyntaxError
+	if !errors.As(errors.Unwrap(err), &jsonErr) {
+		t.Errorf("errors.Unwrap returned %v, want the JSON syntax error", errors.Unwrap(err))
+	}
+	if !errors.Is(err, ErrServer) {
+		t.Errorf("errors.Is(%v, ErrServer) = false, want true", err)
+	}
+}
+
+func TestRateLimitError_Is(t *testing.T) {
+	res := &http.Response{
+		Request:    &http.Request{},
+		StatusCode: http.StatusTooManyRequests,
+		Header:     make(http.Header),
+		Body:       ioutil.NopCloser(strings.NewReader(`{"message":"API rate limit exceeded for 127.0.0.1"}`)),
+	}
+	err := CheckResponse(res)
+
+	var rerr *RateLimitError
+	if !errors.As(err, &rerr) {
+		t.Fatalf("errors.As(%v, *RateLimitError) = false, want true", err)
+	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("errors.Is(%v, ErrRateLimited) = false, want true", err)
+	}
+	if errors.Is(err, ErrServer) {
+		t.Errorf("errors.Is(%v, ErrServer) = true, want false", err)
+	}
+}
+
+func TestAddOptions(t *testing.T) {
+	type opts struct {
+		Sort    string `url:"sort,omitempty"`
+		Include string `url:"include"`
+		WIP     bool   `url:"wip,omitempty"`
+		Ignored string
+		ListOptions
+	}
+
+	tests := []struct {
+		in   interface{}
+		want string
+	}{
+		{(*ListOptions)(nil), "teams"},
+		{&ListOptions{}, "teams"},
+		{&ListOptions{Page: 2, PerPage: 100}, "teams?page=2&per_page=100"},
+		{&opts{Sort: "joined", WIP: true, Ignored: "x"}, "teams?include=&sort=joined&wip=true"},
+		{&opts{ListOptions: ListOptions{Page: 3}}, "teams?include=&page=3"},
+	}
+
+	for _, tt := range tests {
+		got, err := addOptions("teams", tt.in)
+		if err != nil {
+			t.Errorf("addOptions(%+v) returned unexpected error: %v", tt.in, err)
+		}
+		if got != tt.want {
+			t.Errorf("addOptions(%+v) returned %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAddOptions_invalid(t *testing.T) {
+	type opts struct {
+		Values []string `url:"values"`
+	}
+
+	if _, err := addOptions("teams", &opts{}); err == nil {
+		t.Error("Expected error to be returned.")
+	}
+	if _, err := addOptions("teams", "not struct"); err == nil {
+		t.Error("Expected error to be returned.")
+	}
+}
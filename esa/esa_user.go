@@ -0,0 +1,53 @@
+package esa
+
+import "context"
+
+// UserService provides access to the authenticated user related functions
+// in the esa API.
+//
+// API docs: https://docs.esa.io/posts/102#15-0-0
+type UserService service
+
+// User represents an esa user.
+type User struct {
+	ID         int       `json:"id"`
+	Name       string    `json:"name"`
+	ScreenName string    `json:"screen_name"`
+	CreatedAt  Timestamp `json:"created_at"`
+	UpdatedAt  Timestamp `json:"updated_at"`
+	Icon       string    `json:"icon"`
+	Email      string    `json:"email"`
+	Teams      []*Team   `json:"teams,omitempty"`
+}
+
+func (u User) String() string {
+	return Stringify(u)
+}
+
+// UserOptions specifies the optional parameters to the UserService.Get method.
+type UserOptions struct {
+	// Include embeds related resources into the user.
+	// Possible values are: teams
+	Include string `url:"include,omitempty"`
+}
+
+// Get fetches the authenticated user.
+//
+// API docs: https://docs.esa.io/posts/102#15-1-0
+func (s *UserService) Get(ctx context.Context, opts *UserOptions) (*User, *Response, error) {
+	u, err := addOptions("user", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := &User{}
+	resp, err := s.client.Do(ctx, req, user)
+	if err != nil {
+		return nil, resp, err
+	}
+	return user, resp, nil
+}
@@ -0,0 +1,110 @@
+package esa
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUserService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/user", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{})
+		fmt.Fprint(w, `{
+  "id": 1,
+  "name": "Atsuo Fukaya",
+  "screen_name": "fukayatsu",
+  "created_at": "2014-05-10T11:50:07+09:00",
+  "updated_at": "2016-04-17T12:35:16+09:00",
+  "icon": "https://img.esa.io/uploads/production/users/1/icon/thumb_s_402685a258cf2a33c1d6c13a89adec92.png",
+  "email": "fukayatsu@esa.io"
+}`)
+	})
+
+	user, _, err := client.User.Get(context.Background(), nil)
+	if err != nil {
+		t.Errorf("User.Get returned error: %v", err)
+	}
+
+	want := &User{
+		ID:         1,
+		Name:       "Atsuo Fukaya",
+		ScreenName: "fukayatsu",
+		CreatedAt:  Timestamp{time.Date(2014, 5, 10, 11, 50, 7, 0, jst).Local()},
+		UpdatedAt:  Timestamp{time.Date(2016, 4, 17, 12, 35, 16, 0, jst).Local()},
+		Icon:       "https://img.esa.io/uploads/production/users/1/icon/thumb_s_402685a258cf2a33c1d6c13a89adec92.png",
+		Email:      "fukayatsu@esa.io",
+	}
+	if !reflect.DeepEqual(user, want) {
+		t.Errorf("UserService.Get returned %+v, want %+v", user, want)
+	}
+}
+
+func TestUserService_Get_includeTeams(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/user", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"include": "teams"})
+		fmt.Fprint(w, `{
+  "id": 1,
+  "screen_name": "fukayatsu",
+  "teams": [
+    {
+      "name": "docs",
+      "privacy": "open",
+      "description": "esa.io official documents",
+      "icon": "https://img.esa.io/uploads/production/teams/105/icon/thumb_m_0537ab827c4b0c18b60af6cdd94f239c.png",
+      "url": "https://docs.esa.io/"
+    }
+  ]
+}`)
+	})
+
+	user, _, err := client.User.Get(context.Background(), &UserOptions{Include: "teams"})
+	if err != nil {
+		t.Errorf("User.Get returned error: %v", err)
+	}
+
+	want := &User{
+		ID:         1,
+		ScreenName: "fukayatsu",
+		Teams: []*Team{
+			{
+				Name:        "docs",
+				Privacy:     "open",
+				Description: "esa.io official documents",
+				Icon:        "https://img.esa.io/uploads/production/teams/105/icon/thumb_m_0537ab827c4b0c18b60af6cdd94f239c.png",
+				URL:         "https://docs.esa.io/",
+			},
+		},
+	}
+	if !reflect.DeepEqual(user, want) {
+		t.Errorf("UserService.Get returned %+v, want %+v", user, want)
+	}
+}
+
+func TestUserService_Get_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/user", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+	})
+
+	_, resp, err := client.User.Get(context.Background(), nil)
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("UserService.Get returned Reponse, too")
+	}
+}
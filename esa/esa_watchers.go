@@ -0,0 +1,110 @@
+package esa
+
+import (
+	"context"
+	"fmt"
+)
+
+// WatchersService provides access to the watch related functions
+// in the esa API.
+//
+// API docs: https://docs.esa.io/posts/102#10-0-0
+type WatchersService service
+
+// Watcher represents a user who watches a post.
+type Watcher struct {
+	CreatedAt Timestamp `json:"created_at"`
+	User      *Author   `json:"user"`
+}
+
+func (w Watcher) String() string {
+	return Stringify(w)
+}
+
+// WatcherList represents a list of watchers.
+type WatcherList struct {
+	Watchers   []*Watcher `json:"watchers"`
+	PrevPage   int        `json:"prev_page"`
+	NextPage   int        `json:"next_page"`
+	TotalCount int        `json:"total_count"`
+	Page       int        `json:"page"`
+	PerPage    int        `json:"per_page"`
+	MaxPerPage int        `json:"max_per_page"`
+}
+
+func (l WatcherList) String() string {
+	return Stringify(l)
+}
+
+// List lists users who watch a post.
+//
+// API docs: https://docs.esa.io/posts/102#10-1-0
+func (s *WatchersService) List(ctx context.Context, team string, number int, opts *ListOptions) (*WatcherList, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("teams/%s/posts/%d/watchers", s.client.teamName(team), number), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	l := &WatcherList{}
+	resp, err := s.client.Do(ctx, req, l)
+	if err != nil {
+		return nil, resp, err
+	}
+	return l, resp, nil
+}
+
+// ListAll returns an Iterator over all watchers of a post, starting from the
+// page specified by opts.
+func (s *WatchersService) ListAll(team string, number int, opts *ListOptions) *Iterator[*Watcher] {
+	o := ListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return newIterator(o.Page, func(ctx context.Context, page int) ([]*Watcher, int, *Response, error) {
+		o.Page = page
+		l, resp, err := s.List(ctx, team, number, &o)
+		if err != nil {
+			return nil, 0, resp, err
+		}
+		return l.Watchers, l.NextPage, resp, nil
+	})
+}
+
+// Watch watches a post.
+//
+// API docs: https://docs.esa.io/posts/102#10-2-0
+func (s *WatchersService) Watch(ctx context.Context, team string, number int) (*Response, error) {
+	u := fmt.Sprintf("teams/%s/posts/%d/watch", s.client.teamName(team), number)
+	req, err := s.client.NewRequest("POST", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// Unwatch stops watching a post.
+//
+// API docs: https://docs.esa.io/posts/102#10-3-0
+func (s *WatchersService) Unwatch(ctx context.Context, team string, number int) (*Response, error) {
+	u := fmt.Sprintf("teams/%s/posts/%d/watch", s.client.teamName(team), number)
+	req, err := s.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
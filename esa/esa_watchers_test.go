@@ -0,0 +1,157 @@
+package esa
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWatchersService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/2312/watchers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"page": "1", "per_page": "20"})
+		fmt.Fprint(w, `{
+  "watchers": [
+    {
+      "created_at": "2016-05-05T11:40:54+09:00",
+      "user": {
+        "name": "Atsuo Fukaya",
+        "screen_name": "fukayatsu",
+        "icon": "https://img.esa.io/uploads/production/users/1/icon/thumb_s_402685a258cf2a33c1d6c13a89adec92.png"
+      }
+    }
+  ],
+  "prev_page": null,
+  "next_page": null,
+  "total_count": 1,
+  "page": 1,
+  "per_page": 20,
+  "max_per_page": 100
+}`)
+	})
+
+	opts := &ListOptions{Page: 1, PerPage: 20}
+	list, _, err := client.Watchers.List(context.Background(), "hoge", 2312, opts)
+	if err != nil {
+		t.Errorf("Watchers.List returned error: %v", err)
+	}
+
+	want := &WatcherList{
+		Watchers: []*Watcher{
+			{
+				CreatedAt: Timestamp{time.Date(2016, 5, 5, 11, 40, 54, 0, jst).Local()},
+				User: &Author{
+					Name:       "Atsuo Fukaya",
+					ScreenName: "fukayatsu",
+					Icon:       "https://img.esa.io/uploads/production/users/1/icon/thumb_s_402685a258cf2a33c1d6c13a89adec92.png",
+				},
+			},
+		},
+		TotalCount: 1,
+		Page:       1,
+		PerPage:    20,
+		MaxPerPage: 100,
+	}
+	if !reflect.DeepEqual(list, want) {
+		t.Errorf("WatchersService.List returned %+v, want %+v", list, want)
+	}
+}
+
+func TestWatchersService_List_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/2312/watchers", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	_, resp, err := client.Watchers.List(context.Background(), "hoge", 2312, nil)
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("WatchersService.List returned Reponse, too")
+	}
+}
+
+func TestWatchersService_ListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pages := map[string]string{
+		"1": `{"watchers": [{"user": {"screen_name": "fukayatsu"}}], "next_page": 2}`,
+		"2": `{"watchers": [{"user": {"screen_name": "jiroc"}}], "prev_page": 1, "next_page": null}`,
+	}
+	mux.HandleFunc("/v1/teams/hoge/posts/2312/watchers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, pages[r.URL.Query().Get("page")])
+	})
+
+	watchers, err := client.Watchers.ListAll("hoge", 2312, nil).Collect(context.Background())
+	if err != nil {
+		t.Errorf("Watchers.ListAll returned error: %v", err)
+	}
+
+	want := []*Watcher{
+		{User: &Author{ScreenName: "fukayatsu"}},
+		{User: &Author{ScreenName: "jiroc"}},
+	}
+	if !reflect.DeepEqual(watchers, want) {
+		t.Errorf("WatchersService.ListAll returned %+v, want %+v", watchers, want)
+	}
+}
+
+func TestWatchersService_Watch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/2312/watch", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Watchers.Watch(context.Background(), "hoge", 2312)
+	if err != nil {
+		t.Errorf("Watchers.Watch returned error: %v", err)
+	}
+}
+
+func TestWatchersService_Watch_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/2312/watch", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	resp, err := client.Watchers.Watch(context.Background(), "hoge", 2312)
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	if resp == nil {
+		t.Error("WatchersService.Watch returned Reponse, too")
+	}
+}
+
+func TestWatchersService_Unwatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts/2312/watch", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Watchers.Unwatch(context.Background(), "hoge", 2312)
+	if err != nil {
+		t.Errorf("Watchers.Unwatch returned error: %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package esa
+
+import "context"
+
+// Iterator walks through every item of a paginated list endpoint, fetching
+// the following page only when the current one is exhausted.
+//
+//	it := client.Teams.ListAll(nil)
+//	for it.Next(ctx) {
+//		team := it.Value()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+type Iterator[T any] struct {
+	fetch func(ctx context.Context, page int) ([]T, int, *Response, error)
+
+	items []T
+	cur   T
+	page  int // page to fetch next, 0 when the last page has been fetched
+	resp  *Response
+	err   error
+}
+
+// newIterator returns an Iterator starting from page. fetch returns the items
+// of the given page along with the number of the next page.
+func newIterator[T any](page int, fetch func(ctx context.Context, page int) ([]T, int, *Response, error)) *Iterator[T] {
+	if page <= 0 {
+		page = 1
+	}
+	return &Iterator[T]{fetch: fetch, page: page}
+}
+
+// Next advances the iterator to the next item, which will then be available
+// through Value. It returns false when there are no more items or an error
+// occurred, in which case Err reports it.
+//
+// The provided ctx must be non-nil. It is checked before each page is
+// fetched, so a canceled ctx stops the iteration.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	for len(it.items) == 0 {
+		if it.page == 0 {
+			return false
+		}
+		if err := ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		items, next, resp, err := it.fetch(ctx, it.page)
+		it.resp = resp
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if next <= it.page {
+			// Guard against a server pointing back to a page already fetched.
+			next = 0
+		}
+		it.items, it.page = items, next
+	}
+
+	it.cur, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Collect advances the iterator until the end and returns all the remaining
+// items. If an error occurs, the items collected so far are returned along
+// with the error.
+func (it *Iterator[T]) Collect(ctx context.Context) ([]T, error) {
+	var items []T
+	for it.Next(ctx) {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.cur
+}
+
+// Err returns the error, if any, that was encountered during iteration.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Response returns the response of the most recently fetched page.
+func (it *Iterator[T]) Response() *Response {
+	return it.resp
+}
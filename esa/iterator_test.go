@@ -0,0 +1,167 @@
+package esa
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestIterator_pages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pages := map[string]string{
+		"1": `{"teams": [{"name": "a"}, {"name": "b"}], "next_page": 2}`,
+		"2": `{"teams": [], "prev_page": 1, "next_page": 3}`,
+		"3": `{"teams": [{"name": "c"}], "prev_page": 2, "next_page": null}`,
+	}
+	var requested []string
+	mux.HandleFunc("/v1/teams", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		page := r.URL.Query().Get("page")
+		requested = append(requested, page)
+		if got, want := r.URL.Query().Get("per_page"), "2"; got != want {
+			t.Errorf("per_page = %v, want %v", got, want)
+		}
+		fmt.Fprint(w, pages[page])
+	})
+
+	it := client.Teams.ListAll(&ListOptions{PerPage: 2})
+	var names []string
+	for it.Next(context.Background()) {
+		names = append(names, it.Value().Name)
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Iterator.Err returned %v", err)
+	}
+
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Iterator returned %v, want %v", names, want)
+	}
+	if want := []string{"1", "2", "3"}; !reflect.DeepEqual(requested, want) {
+		t.Errorf("Iterator requested pages %v, want %v", requested, want)
+	}
+	if it.Response() == nil {
+		t.Error("Iterator.Response returned nil")
+	}
+
+	// An exhausted iterator must not make further requests.
+	if it.Next(context.Background()) {
+		t.Error("Iterator.Next returned true after the last page")
+	}
+	if len(requested) != 3 {
+		t.Errorf("Iterator made %d requests, want 3", len(requested))
+	}
+}
+
+func TestIterator_startPage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"page": "5"})
+		fmt.Fprint(w, `{"teams": [{"name": "a"}], "next_page": null}`)
+	})
+
+	it := client.Teams.ListAll(&ListOptions{Page: 5})
+	if !it.Next(context.Background()) {
+		t.Fatalf("Iterator.Next returned false: %v", it.Err())
+	}
+	if it.Next(context.Background()) {
+		t.Error("Iterator.Next returned true after the last page")
+	}
+}
+
+func TestIterator_errorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(w, `{"teams": [{"name": "a"}], "next_page": 2}`)
+	})
+
+	it := client.Teams.ListAll(nil)
+	var n int
+	for it.Next(context.Background()) {
+		n++
+	}
+	if n != 1 {
+		t.Errorf("Iterator returned %d items, want 1", n)
+	}
+	if it.Err() == nil {
+		t.Error("Expected error to be returned.")
+	}
+	if it.Response() == nil {
+		t.Error("Iterator.Response returned nil")
+	}
+}
+
+func TestIterator_canceledContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mux.HandleFunc("/v1/teams", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "1" {
+			t.Errorf("Unexpected request for page %v", r.URL.Query().Get("page"))
+		}
+		fmt.Fprint(w, `{"teams": [{"name": "a"}], "next_page": 2}`)
+	})
+
+	it := client.Teams.ListAll(nil)
+	if !it.Next(ctx) {
+		t.Fatalf("Iterator.Next returned false: %v", it.Err())
+	}
+	cancel()
+	if it.Next(ctx) {
+		t.Error("Iterator.Next returned true after ctx was canceled")
+	}
+	if got, want := it.Err(), context.Canceled; got != want {
+		t.Errorf("Iterator.Err returned %v, want %v", got, want)
+	}
+}
+
+func TestIterator_nextPageLoop(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var n int
+	mux.HandleFunc("/v1/teams", func(w http.ResponseWriter, r *http.Request) {
+		n++
+		fmt.Fprint(w, `{"teams": [{"name": "a"}], "next_page": 1}`)
+	})
+
+	it := client.Teams.ListAll(nil)
+	for it.Next(context.Background()) {
+	}
+	if n != 1 {
+		t.Errorf("Iterator made %d requests, want 1", n)
+	}
+}
+
+func TestIterator_Collect_error(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, `{"teams": [{"name": "a"}], "next_page": 2}`)
+	})
+
+	teams, err := client.Teams.ListAll(nil).Collect(context.Background())
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+	if len(teams) != 1 || teams[0].Name != "a" {
+		t.Errorf("Iterator.Collect returned %v, want the items of the first page", teams)
+	}
+}
@@ -0,0 +1,154 @@
+package esa
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+// maxDumpSize is the maximum number of bytes of a body dumped to the log.
+const maxDumpSize = 8 << 10
+
+// redactedHeaders are the headers never written to the log.
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization"}
+
+// ErrorKind classifies an error returned by Client.Do for logs and metrics.
+// It returns one of "not_found", "unauthorized", "forbidden", "conflict",
+// "validation", "rate_limited", "server", "canceled", "timeout", "network"
+// and "other", or an empty string if err is nil.
+func ErrorKind(err error) string {
+	var nerr net.Error
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrNotFound):
+		return "not_found"
+	case errors.Is(err, ErrUnauthorized):
+		return "unauthorized"
+	case errors.Is(err, ErrForbidden):
+		return "forbidden"
+	case errors.Is(err, ErrConflict):
+		return "conflict"
+	case errors.Is(err, ErrValidation):
+		return "validation"
+	case errors.Is(err, ErrRateLimited):
+		return "rate_limited"
+	case errors.Is(err, ErrServer):
+		return "server"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.As(err, &nerr):
+		if nerr.Timeout() {
+			return "timeout"
+		}
+		return "network"
+	}
+	return "other"
+}
+
+// logRoundTrip wraps next to write a record per API call to c.logger, along
+// with dumps of the request and the response at debug level.
+func (c *Client) logRoundTrip(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*Response, error) {
+		ctx := req.Context()
+		u := *req.URL // sanitizeURL modifies the URL in place
+		url := sanitizeURL(&u).String()
+		debug := c.logger.Enabled(ctx, slog.LevelDebug)
+
+		if debug {
+			c.logger.LogAttrs(ctx, slog.LevelDebug, "esa: request dump",
+				slog.String("method", req.Method),
+				slog.String("url", url),
+				slog.Any("header", redactHeader(req.Header)),
+				slog.String("body", dumpRequestBody(req)))
+		}
+
+		start := time.Now()
+		resp, err := next(req)
+		latency := time.Since(start)
+
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("url", url),
+			slog.Duration("latency", latency),
+		}
+		if resp != nil && resp.Response != nil {
+			attrs = append(attrs,
+				slog.Int("status", resp.StatusCode),
+				slog.Int("attempts", resp.Attempts),
+				slog.Int("rate_remaining", resp.Rate.Remaining),
+				slog.Bool("from_cache", resp.FromCache))
+		}
+		level := slog.LevelInfo
+		if err != nil {
+			level = slog.LevelError
+			attrs = append(attrs,
+				slog.String("error_kind", ErrorKind(err)),
+				slog.Any("error", err))
+		}
+
+		if debug && resp != nil && resp.Response != nil {
+			c.logger.LogAttrs(ctx, slog.LevelDebug, "esa: response dump",
+				slog.String("method", req.Method),
+				slog.String("url", url),
+				slog.Int("status", resp.StatusCode),
+				slog.Any("header", redactHeader(resp.Header)),
+				slog.String("body", dumpResponseBody(resp)))
+		}
+		c.logger.LogAttrs(ctx, level, "esa: request", attrs...)
+		return resp, err
+	}
+}
+
+// redactHeader returns a copy of h with the credentials redacted.
+func redactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for _, k := range redactedHeaders {
+		if h.Get(k) != "" {
+			h.Set(k, "REDACTED")
+		}
+	}
+	return h
+}
+
+func dumpRequestBody(req *http.Request) string {
+	if req.GetBody == nil {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	data, _ := ioutil.ReadAll(body)
+	return truncateDump(data)
+}
+
+// dumpResponseBody reads the body of resp, replacing it with a copy left
+// for Client.Do to decode.
+func dumpResponseBody(resp *Response) string {
+	if resp.Body == nil {
+		return ""
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return ""
+	}
+	return truncateDump(data)
+}
+
+func truncateDump(data []byte) string {
+	if len(data) > maxDumpSize {
+		return string(data[:maxDumpSize]) + "...(truncated)"
+	}
+	return string(data)
+}
@@ -0,0 +1,208 @@
+package esa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// logRecords decodes the JSON records written to buf.
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		r := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("Invalid log record %q: %v", line, err)
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+func TestClient_logging(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge/posts", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "75")
+		w.Header().Set(headerRateRemaining, "74")
+		w.Header().Set(headerRateReset, "1504574600")
+		fmt.Fprint(w, `{"number": 1, "name": "hi!"}`)
+	})
+
+	buf := new(bytes.Buffer)
+	client.logger = slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	req, _ := client.NewRequest("POST", "teams/hoge/posts?access_token=secret", &postRequest{Post: &PostRequest{Name: "hi!"}})
+	req.Header.Set("Authorization", "Bearer secret")
+	p := &Post{}
+	if _, err := client.Do(context.Background(), req, p); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if p.Name != "hi!" {
+		t.Errorf("Do decoded %+v after dumping the response", p)
+	}
+
+	if s := buf.String(); strings.Contains(s, "secret") {
+		t.Errorf("Log contains the access token: %s", s)
+	}
+	records := logRecords(t, buf)
+	if len(records) != 3 {
+		t.Fatalf("Logged %d records, want 3: %v", len(records), records)
+	}
+	if got, want := records[0]["msg"], "esa: request dump"; got != want {
+		t.Errorf("msg is %v, want %v", got, want)
+	}
+	if got := records[0]["body"]; !strings.Contains(fmt.Sprint(got), `"name":"hi!"`) {
+		t.Errorf("Request dump body is %v", got)
+	}
+	if got := records[1]["body"]; got != `{"number": 1, "name": "hi!"}` {
+		t.Errorf("Response dump body is %v", got)
+	}
+
+	r := records[2]
+	for k, want := range map[string]interface{}{
+		"level":          "INFO",
+		"msg":            "esa: request",
+		"method":         "POST",
+		"url":            server.URL + "/v1/teams/hoge/posts?access_token=REDACTED",
+		"status":         float64(200),
+		"attempts":       float64(1),
+		"rate_remaining": float64(74),
+		"from_cache":     false,
+	} {
+		if got := r[k]; got != want {
+			t.Errorf("%v is %v, want %v", k, got, want)
+		}
+	}
+	if _, ok := r["latency"]; !ok {
+		t.Error("Record does not have latency")
+	}
+}
+
+func TestClient_logging_error(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": "not_found", "message": "Not found"}`, http.StatusNotFound)
+	})
+
+	buf := new(bytes.Buffer)
+	client.logger = slog.New(slog.NewJSONHandler(buf, nil))
+
+	if _, _, err := client.Teams.Get(context.Background(), "hoge"); err == nil {
+		t.Fatal("Expected error to be returned.")
+	}
+
+	records := logRecords(t, buf)
+	if len(records) != 1 {
+		t.Fatalf("Logged %d records, want 1 without dumps: %v", len(records), records)
+	}
+	for k, want := range map[string]interface{}{
+		"level":      "ERROR",
+		"status":     float64(404),
+		"error_kind": "not_found",
+	} {
+		if got := records[0][k]; got != want {
+			t.Errorf("%v is %v, want %v", k, got, want)
+		}
+	}
+}
+
+func TestClient_logging_errorDump(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": "not_found", "message": "Not found"}`)
+	})
+
+	buf := new(bytes.Buffer)
+	client.logger = slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	if _, _, err := client.Teams.Get(context.Background(), "hoge"); err == nil {
+		t.Fatal("Expected error to be returned.")
+	}
+
+	records := logRecords(t, buf)
+	if len(records) != 3 {
+		t.Fatalf("Logged %d records, want 3: %v", len(records), records)
+	}
+	if got, want := records[1]["msg"], "esa: response dump"; got != want {
+		t.Errorf("msg is %v, want %v", got, want)
+	}
+	if got, want := records[1]["body"], `{"error": "not_found", "message": "Not found"}`; got != want {
+		t.Errorf("Response dump body is %v, want %v", got, want)
+	}
+}
+
+func TestClient_logging_cache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams/hoge", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"abc"`)
+		fmt.Fprint(w, `{"name": "hoge"}`)
+	})
+
+	buf := new(bytes.Buffer)
+	client.logger = slog.New(slog.NewJSONHandler(buf, nil))
+	client.cache = NewMemoryCache()
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Teams.Get(context.Background(), "hoge"); err != nil {
+			t.Fatalf("Teams.Get returned error: %v", err)
+		}
+	}
+
+	records := logRecords(t, buf)
+	if len(records) != 2 {
+		t.Fatalf("Logged %d records, want 2: %v", len(records), records)
+	}
+	r := records[1]
+	for k, want := range map[string]interface{}{
+		"level":      "INFO",
+		"status":     float64(200),
+		"from_cache": true,
+	} {
+		if got := r[k]; got != want {
+			t.Errorf("%v is %v, want %v", k, got, want)
+		}
+	}
+	if err, ok := r["error"]; ok {
+		t.Errorf("Cache hit is logged with error %v", err)
+	}
+}
+
+func TestErrorKind(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, ""},
+		{&ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}, "not_found"},
+		{&ErrorResponse{Response: &http.Response{StatusCode: http.StatusUnprocessableEntity}}, "validation"},
+		{&ErrorResponse{Response: &http.Response{StatusCode: http.StatusBadGateway}}, "server"},
+		{&RateLimitError{}, "rate_limited"},
+		{context.Canceled, "canceled"},
+		{context.DeadlineExceeded, "timeout"},
+		{fmt.Errorf("esa: category %q must be an absolute path", "foo"), "other"},
+	}
+
+	for _, tt := range tests {
+		if got := ErrorKind(tt.err); got != tt.want {
+			t.Errorf("ErrorKind(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package esa
+
+import "net/http"
+
+// RoundTripFunc sends an API request and returns its response. The context
+// of the request is the one given to Client.Do.
+//
+// The body of the returned response is decoded by Client.Do after the
+// middleware chain returns, so a middleware reading it must replace it with
+// an equivalent one.
+type RoundTripFunc func(req *http.Request) (*Response, error)
+
+// Middleware wraps the sending of API requests to add cross-cutting behavior
+// such as logging, tracing or metrics. A middleware sees the request built by
+// NewRequest and the response with its Rate, once per call of Client.Do
+// including all the retries.
+//
+//	client.Use(func(next esa.RoundTripFunc) esa.RoundTripFunc {
+//		return func(req *http.Request) (*esa.Response, error) {
+//			req.Header.Set("X-Request-Id", newRequestID())
+//			return next(req)
+//		}
+//	})
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// Use appends middleware to the chain wrapping Do. The first middleware
+// registered is the outermost one. Use must not be called concurrently with
+// requests.
+func (c *Client) Use(mw ...Middleware) {
+	c.middleware = append(c.middleware, mw...)
+}
+
+// handler returns the round trip of the client wrapped by its middleware.
+func (c *Client) handler() RoundTripFunc {
+	h := RoundTripFunc(c.roundTrip)
+	if c.cache != nil {
+		h = c.cacheRoundTrip(h)
+	}
+	// The logger sees the responses served from the cache as they are
+	// returned by Do, not the 304 Not Modified behind them.
+	if c.logger != nil {
+		h = c.logRoundTrip(h)
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	return h
+}
@@ -0,0 +1,124 @@
+package esa
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClient_Use(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("X-Trace"), "outer,inner"; got != want {
+			t.Errorf("X-Trace is %v, want %v", got, want)
+		}
+		w.Header().Set(headerRateLimit, "75")
+		w.Header().Set(headerRateRemaining, "74")
+		w.Header().Set(headerRateReset, "1504574600")
+		fmt.Fprint(w, `{"teams": [{"name": "hoge"}]}`)
+	})
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*Response, error) {
+				calls = append(calls, name)
+				if v := req.Header.Get("X-Trace"); v != "" {
+					name = v + "," + name
+				}
+				req.Header.Set("X-Trace", name)
+				resp, err := next(req)
+				if err != nil {
+					t.Errorf("%v: next returned error: %v", name, err)
+				}
+				if got, want := resp.Rate.Remaining, 74; got != want {
+					t.Errorf("%v: Rate.Remaining is %v, want %v", name, got, want)
+				}
+				return resp, err
+			}
+		}
+	}
+	client.Use(trace("outer"), trace("inner"))
+
+	list, _, err := client.Teams.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Teams.List returned error: %v", err)
+	}
+	if want := []string{"outer", "inner"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("Middleware called in order %v, want %v", calls, want)
+	}
+	if len(list.Teams) != 1 || list.Teams[0].Name != "hoge" {
+		t.Errorf("Teams.List returned %+v after middleware", list)
+	}
+}
+
+func TestClient_Use_retries(t *testing.T) {
+	setup()
+	defer teardown()
+	fakeSleep(client)
+	client.RetryPolicy = DefaultRetryPolicy()
+
+	var n int
+	mux.HandleFunc("/v1/teams", func(w http.ResponseWriter, r *http.Request) {
+		n++
+		if n == 1 {
+			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"teams": []}`)
+	})
+
+	var calls int
+	client.Use(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*Response, error) {
+			calls++
+			resp, err := next(req)
+			if resp == nil || resp.Attempts != 2 {
+				t.Errorf("Middleware got Response %v, want 2 attempts", resp)
+			}
+			return resp, err
+		}
+	})
+
+	if _, _, err := client.Teams.List(context.Background(), nil); err != nil {
+		t.Fatalf("Teams.List returned error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Middleware called %d times, want once per Do", calls)
+	}
+}
+
+func TestWithMiddleware_shortCircuit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Middleware did not short-circuit the request")
+	})
+
+	mw := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*Response, error) {
+			return &Response{Response: &http.Response{
+				StatusCode: http.StatusOK,
+				Request:    req,
+				Header:     make(http.Header),
+				Body:       ioutil.NopCloser(strings.NewReader(`{"teams": [{"name": "cached"}]}`)),
+			}}, nil
+		}
+	}
+	c := NewClient(nil, WithBaseURL(client.BaseURL), WithMiddleware(mw))
+
+	list, _, err := c.Teams.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Teams.List returned error: %v", err)
+	}
+	if len(list.Teams) != 1 || list.Teams[0].Name != "cached" {
+		t.Errorf("Teams.List returned %+v, want the response of the middleware", list)
+	}
+}
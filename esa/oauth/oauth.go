@@ -0,0 +1,222 @@
+// Package oauth provides helpers for the OAuth authorization flow of esa API v1,
+// letting each user of an application authorize it with their own account.
+//
+//	conf := &oauth.Config{
+//		ClientID:     os.Getenv("ESA_CLIENT_ID"),
+//		ClientSecret: os.Getenv("ESA_CLIENT_SECRET"),
+//		RedirectURL:  "https://example.com/callback",
+//		Scopes:       []oauth.Scope{oauth.ScopeRead, oauth.ScopeWrite},
+//	}
+//
+//	// Redirect the user to conf.AuthCodeURL(state), then on the callback:
+//	token, err := conf.Exchange(ctx, r.FormValue("code"))
+//	if err != nil {
+//		log.Panic(err)
+//	}
+//	client := esa.NewClient(nil, token.ClientOption())
+//
+// ref. https://docs.esa.io/posts/102#3-0-0
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/iwata/go-esa/esa"
+)
+
+const baseURL = "https://api.esa.io/"
+
+// Scope represents a permission an application requests.
+type Scope string
+
+// Scopes supported by esa.
+const (
+	ScopeRead         Scope = "read"
+	ScopeWrite        Scope = "write"
+	ScopeAdminComment Scope = "admin:comment"
+)
+
+// Config describes an OAuth application registered on esa.
+type Config struct {
+	// ClientID and ClientSecret are the credentials of the application.
+	ClientID     string
+	ClientSecret string
+
+	// RedirectURL is the URL esa redirects users to after authorization.
+	RedirectURL string
+
+	// Scopes specifies the permissions requested to users.
+	Scopes []Scope
+
+	// BaseURL is the base URL of the esa API. If nil, https://api.esa.io/ is used.
+	BaseURL *url.URL
+
+	// HTTPClient makes the requests. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// Token represents an access token issued to the application.
+type Token struct {
+	AccessToken string        `json:"access_token"`
+	TokenType   string        `json:"token_type"`
+	Scope       string        `json:"scope"`
+	CreatedAt   esa.Timestamp `json:"created_at"`
+}
+
+func (t Token) String() string {
+	return esa.Stringify(Token{TokenType: t.TokenType, Scope: t.Scope, CreatedAt: t.CreatedAt, AccessToken: "REDACTED"})
+}
+
+// ClientOption returns an esa.ClientOption authenticating an esa.Client
+// with the token.
+func (t *Token) ClientOption() esa.ClientOption {
+	return esa.WithToken(t.AccessToken)
+}
+
+// Transport returns an esa.TokenTransport authenticating requests with the token.
+func (t *Token) Transport() *esa.TokenTransport {
+	return &esa.TokenTransport{Token: t.AccessToken}
+}
+
+// Application represents the application a token is issued to.
+type Application struct {
+	UID string `json:"uid"`
+}
+
+// TokenInfo represents information about an access token.
+type TokenInfo struct {
+	ResourceOwnerID  int           `json:"resource_owner_id"`
+	Scope            []Scope       `json:"scope"`
+	ExpiresInSeconds *int          `json:"expires_in_seconds"`
+	Application      *Application  `json:"application"`
+	CreatedAt        esa.Timestamp `json:"created_at"`
+}
+
+func (i TokenInfo) String() string {
+	return esa.Stringify(i)
+}
+
+// AuthCodeURL returns the URL of the page asking the user to authorize the
+// application. state is given back on the redirect to protect against CSRF.
+func (c *Config) AuthCodeURL(state string) string {
+	v := url.Values{
+		"client_id":     {c.ClientID},
+		"redirect_uri":  {c.RedirectURL},
+		"response_type": {"code"},
+	}
+	if len(c.Scopes) > 0 {
+		scopes := make([]string, len(c.Scopes))
+		for i, s := range c.Scopes {
+			scopes[i] = string(s)
+		}
+		v.Set("scope", strings.Join(scopes, " "))
+	}
+	if state != "" {
+		v.Set("state", state)
+	}
+	return c.endpoint("oauth/authorize") + "?" + v.Encode()
+}
+
+// Exchange converts an authorization code given on the redirect into an
+// access token.
+func (c *Config) Exchange(ctx context.Context, code string) (*Token, error) {
+	v := url.Values{
+		"client_id":     {c.ClientID},
+		"client_secret": {c.ClientSecret},
+		"grant_type":    {"authorization_code"},
+		"redirect_uri":  {c.RedirectURL},
+		"code":          {code},
+	}
+	req, err := http.NewRequest("POST", c.endpoint("oauth/token"), strings.NewReader(v.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	t := &Token{}
+	if err := c.do(ctx, req, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// TokenInfo fetches information about an access token.
+func (c *Config) TokenInfo(ctx context.Context, accessToken string) (*TokenInfo, error) {
+	req, err := http.NewRequest("GET", c.endpoint("oauth/token/info"), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	i := &TokenInfo{}
+	if err := c.do(ctx, req, i); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// Revoke revokes an access token.
+func (c *Config) Revoke(ctx context.Context, accessToken string) error {
+	v := url.Values{
+		"client_id":     {c.ClientID},
+		"client_secret": {c.ClientSecret},
+		"token":         {accessToken},
+	}
+	req, err := http.NewRequest("POST", c.endpoint("oauth/revoke"), strings.NewReader(v.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return c.do(ctx, req, nil)
+}
+
+func (c *Config) endpoint(path string) string {
+	base := c.BaseURL
+	if base == nil {
+		base, _ = url.Parse(baseURL)
+	}
+	return base.ResolveReference(&url.URL{Path: "/" + path}).String()
+}
+
+// do sends req and decodes the JSON response body into v. API errors are
+// reported the same way as esa.Client does.
+func (c *Config) do(ctx context.Context, req *http.Request, v interface{}) error {
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		return err
+	}
+	defer func() {
+		// Drain up to 512 bytes and close the body to let the Transport reuse the connection
+		_, _ = io.CopyN(ioutil.Discard, resp.Body, 512)
+		_ = resp.Body.Close()
+	}()
+
+	if err := esa.CheckResponse(resp); err != nil {
+		return err
+	}
+	if v == nil {
+		return nil
+	}
+	err = json.NewDecoder(resp.Body).Decode(v)
+	if err == io.EOF {
+		err = nil // ignore EOF errors caused by empty response body
+	}
+	return err
+}
@@ -0,0 +1,209 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iwata/go-esa/esa"
+)
+
+var (
+	// mux is the HTTP request multiplexer used with the test server.
+	mux *http.ServeMux
+
+	// conf is the OAuth application configured to talk to the test server.
+	conf *Config
+
+	// server is a test HTTP server used to provide mock API responses.
+	server *httptest.Server
+)
+
+func setup() {
+	mux = http.NewServeMux()
+	server = httptest.NewServer(mux)
+
+	u, _ := url.Parse(server.URL)
+	conf = &Config{
+		ClientID:     "client_id",
+		ClientSecret: "client_secret",
+		RedirectURL:  "https://example.com/callback",
+		Scopes:       []Scope{ScopeRead, ScopeWrite},
+		BaseURL:      u,
+	}
+}
+
+func teardown() {
+	server.Close()
+}
+
+func testFormValues(t *testing.T, r *http.Request, want url.Values) {
+	r.ParseForm()
+	if got := r.Form; !reflect.DeepEqual(got, want) {
+		t.Errorf("Request parameters: %v, want %v", got, want)
+	}
+}
+
+func TestConfig_AuthCodeURL(t *testing.T) {
+	c := &Config{
+		ClientID:    "client_id",
+		RedirectURL: "https://example.com/callback",
+		Scopes:      []Scope{ScopeRead, ScopeAdminComment},
+	}
+
+	u, err := url.Parse(c.AuthCodeURL("state"))
+	if err != nil {
+		t.Fatalf("AuthCodeURL returned invalid URL: %v", err)
+	}
+	if got, want := u.Scheme+"://"+u.Host+u.Path, "https://api.esa.io/oauth/authorize"; got != want {
+		t.Errorf("AuthCodeURL endpoint is %v, want %v", got, want)
+	}
+	want := url.Values{
+		"client_id":     {"client_id"},
+		"redirect_uri":  {"https://example.com/callback"},
+		"response_type": {"code"},
+		"scope":         {"read admin:comment"},
+		"state":         {"state"},
+	}
+	if got := u.Query(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AuthCodeURL parameters: %v, want %v", got, want)
+	}
+}
+
+func TestConfig_Exchange(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Request method: %v, want POST", r.Method)
+		}
+		testFormValues(t, r, url.Values{
+			"client_id":     {"client_id"},
+			"client_secret": {"client_secret"},
+			"grant_type":    {"authorization_code"},
+			"redirect_uri":  {"https://example.com/callback"},
+			"code":          {"code"},
+		})
+		fmt.Fprint(w, `{
+  "access_token": "access_token",
+  "token_type": "Bearer",
+  "scope": "read write",
+  "created_at": 1427941883
+}`)
+	})
+
+	token, err := conf.Exchange(context.Background(), "code")
+	if err != nil {
+		t.Fatalf("Exchange returned error: %v", err)
+	}
+
+	want := &Token{
+		AccessToken: "access_token",
+		TokenType:   "Bearer",
+		Scope:       "read write",
+		CreatedAt:   esa.Timestamp{Time: time.Unix(1427941883, 0)},
+	}
+	if !reflect.DeepEqual(token, want) {
+		t.Errorf("Exchange returned %+v, want %+v", token, want)
+	}
+	if got, want := token.Transport().Token, "access_token"; got != want {
+		t.Errorf("Transport token is %v, want %v", got, want)
+	}
+	if strings.Contains(token.String(), "access_token\"") {
+		t.Errorf("Token.String() = %v contains the access token", token)
+	}
+}
+
+func TestConfig_Exchange_ErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error": "invalid_grant", "error_description": "The provided authorization grant is invalid."}`)
+	})
+
+	_, err := conf.Exchange(context.Background(), "code")
+	if _, ok := err.(*esa.ErrorResponse); !ok {
+		t.Errorf("Expected a *esa.ErrorResponse error; got %#v.", err)
+	}
+}
+
+func TestConfig_TokenInfo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/oauth/token/info", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "Bearer access_token"; got != want {
+			t.Errorf("Authorization is %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{
+  "resource_owner_id": 1,
+  "scope": ["read", "write"],
+  "expires_in_seconds": null,
+  "application": {"uid": "client_id"},
+  "created_at": 1427941883
+}`)
+	})
+
+	info, err := conf.TokenInfo(context.Background(), "access_token")
+	if err != nil {
+		t.Fatalf("TokenInfo returned error: %v", err)
+	}
+
+	want := &TokenInfo{
+		ResourceOwnerID: 1,
+		Scope:           []Scope{ScopeRead, ScopeWrite},
+		Application:     &Application{UID: "client_id"},
+		CreatedAt:       esa.Timestamp{Time: time.Unix(1427941883, 0)},
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("TokenInfo returned %+v, want %+v", info, want)
+	}
+}
+
+func TestConfig_Revoke(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/oauth/revoke", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Request method: %v, want POST", r.Method)
+		}
+		testFormValues(t, r, url.Values{
+			"client_id":     {"client_id"},
+			"client_secret": {"client_secret"},
+			"token":         {"access_token"},
+		})
+		fmt.Fprint(w, `{}`)
+	})
+
+	if err := conf.Revoke(context.Background(), "access_token"); err != nil {
+		t.Errorf("Revoke returned error: %v", err)
+	}
+}
+
+func TestToken_ClientOption(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/user", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "Bearer access_token"; got != want {
+			t.Errorf("Authorization is %v, want %v", got, want)
+		}
+	})
+
+	token := &Token{AccessToken: "access_token"}
+	client := esa.NewClient(nil, esa.WithBaseURL(conf.BaseURL), token.ClientOption())
+	req, _ := client.NewRequest("GET", "user", nil)
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Errorf("Do returned error: %v", err)
+	}
+}
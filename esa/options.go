@@ -0,0 +1,124 @@
+package esa
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// ClientOption configures a Client created by NewClient.
+type ClientOption func(*Client)
+
+// WithBaseURL sets the base URL of the esa API, e.g. to talk to a proxy.
+func WithBaseURL(u *url.URL) ClientOption {
+	return func(c *Client) {
+		c.BaseURL = u
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) ClientOption {
+	return func(c *Client) {
+		c.UserAgent = ua
+	}
+}
+
+// WithToken makes the client authenticate with the access token by wrapping
+// the transport of its http.Client with TokenTransport. If token is empty,
+// it is read from the ESA_ACCESS_TOKEN environment variable.
+//
+// ref. https://docs.esa.io/posts/102#3-0-0
+func WithToken(token string) ClientOption {
+	return func(c *Client) {
+		t := token
+		if t == "" {
+			t = os.Getenv(EnvAccessToken)
+		}
+		if t == "" {
+			return
+		}
+		// Copy the client not to authenticate every user of e.g. http.DefaultClient.
+		hc := *c.client
+		hc.Transport = &TokenTransport{Token: t, Base: hc.Transport}
+		c.client = &hc
+	}
+}
+
+// WithStorageClient sets the HTTP client uploading attachments to the
+// storage of esa. It must not authenticate to the esa API, since the storage
+// is another host. By default, the client of the API is used without the
+// access token. See AttachmentsService.Upload.
+func WithStorageClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.storage = hc
+	}
+}
+
+// WithRetryPolicy sets the policy for retrying failed requests.
+func WithRetryPolicy(p *RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.RetryPolicy = p
+	}
+}
+
+// WithRateLimitPolicy sets what the client does when the rate limit is
+// exhausted.
+func WithRateLimitPolicy(p RateLimitPolicy) ClientOption {
+	return func(c *Client) {
+		c.RateLimitPolicy = p
+	}
+}
+
+// WithLogger sets the logger reporting API calls, retries and rate limit
+// waits. A record is written per API call, and the requests and responses
+// are dumped at debug level. Authorization headers are always redacted.
+func WithLogger(l *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// WithDefaultTeam sets the team used by service methods called with an
+// empty team name.
+func WithDefaultTeam(team string) ClientOption {
+	return func(c *Client) {
+		c.defaultTeam = team
+	}
+}
+
+// WithMiddleware registers middleware wrapping every request.
+// See Client.Use.
+func WithMiddleware(mw ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.Use(mw...)
+	}
+}
+
+// WithCache makes the client store responses in cache and send conditional
+// requests with them, saving the rate limit when resources are unchanged.
+func WithCache(cache Cache) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// WithRateChangeFunc sets a function called whenever a response changes the
+// rate limit of the client. It is called synchronously from Client.Do, and
+// may be called concurrently if requests are.
+func WithRateChangeFunc(fn func(Rate)) ClientOption {
+	return func(c *Client) {
+		c.onRateChange = fn
+	}
+}
+
+// WithSchedulePolicy makes the client schedule requests sent concurrently
+// according to p. The priority of a request is set with WithPriority.
+func WithSchedulePolicy(p *SchedulePolicy) ClientOption {
+	return func(c *Client) {
+		c.scheduler = nil
+		if p != nil {
+			c.scheduler = newScheduler(p, c.Rate)
+		}
+	}
+}
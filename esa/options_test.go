@@ -0,0 +1,133 @@
+package esa
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestNewClient_options(t *testing.T) {
+	u, _ := url.Parse("https://esa.example.com/")
+	retry := DefaultRetryPolicy()
+	logger := slog.New(slog.NewTextHandler(new(bytes.Buffer), nil))
+	cache := NewMemoryCache()
+
+	c := NewClient(nil,
+		WithBaseURL(u),
+		WithUserAgent("esa-bot/1.0"),
+		WithToken("token"),
+		WithRetryPolicy(retry),
+		WithRateLimitPolicy(RateLimitWait),
+		WithLogger(logger),
+		WithDefaultTeam("hoge"),
+		WithCache(cache),
+		WithRateChangeFunc(func(Rate) {}),
+	)
+
+	if got, want := c.BaseURL, u; got != want {
+		t.Errorf("BaseURL is %v, want %v", got, want)
+	}
+	if got, want := c.UserAgent, "esa-bot/1.0"; got != want {
+		t.Errorf("UserAgent is %v, want %v", got, want)
+	}
+	if tr, ok := c.client.Transport.(*TokenTransport); !ok || tr.Token != "token" {
+		t.Errorf("Transport is %v, want *TokenTransport", c.client.Transport)
+	}
+	if got, want := c.RetryPolicy, retry; got != want {
+		t.Errorf("RetryPolicy is %v, want %v", got, want)
+	}
+	if got, want := c.RateLimitPolicy, RateLimitWait; got != want {
+		t.Errorf("RateLimitPolicy is %v, want %v", got, want)
+	}
+	if got, want := c.logger, logger; got != want {
+		t.Errorf("logger is %v, want %v", got, want)
+	}
+	if got, want := c.defaultTeam, "hoge"; got != want {
+		t.Errorf("defaultTeam is %v, want %v", got, want)
+	}
+	if got, want := c.cache, Cache(cache); got != want {
+		t.Errorf("cache is %v, want %v", got, want)
+	}
+	if c.onRateChange == nil {
+		t.Error("onRateChange is nil")
+	}
+}
+
+func TestNewRequest_headers(t *testing.T) {
+	c := NewClient(nil)
+	req, _ := c.NewRequest("GET", "teams", nil)
+	if got, want := req.Header.Get("User-Agent"), defaultUserAgent; got != want {
+		t.Errorf("User-Agent is %v, want %v", got, want)
+	}
+
+	c = NewClient(nil, WithUserAgent("esa-bot/1.0"))
+	req, _ = c.NewRequest("GET", "teams", nil)
+	if got, want := req.Header.Get("User-Agent"), "esa-bot/1.0"; got != want {
+		t.Errorf("User-Agent is %v, want %v", got, want)
+	}
+}
+
+func TestWithDefaultTeam(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithDefaultTeam("hoge")(client)
+	mux.HandleFunc("/v1/teams/hoge/stats", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"members": 20}`)
+	})
+	mux.HandleFunc("/v1/teams/fuga/stats", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"members": 10}`)
+	})
+
+	st, _, err := client.Teams.GetStats(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Teams.GetStats returned error: %v", err)
+	}
+	if got, want := st.Members, 20; got != want {
+		t.Errorf("Teams.GetStats of default team returned %v members, want %v", got, want)
+	}
+
+	st, _, err = client.Teams.GetStats(context.Background(), "fuga")
+	if err != nil {
+		t.Fatalf("Teams.GetStats returned error: %v", err)
+	}
+	if got, want := st.Members, 10; got != want {
+		t.Errorf("Teams.GetStats of explicit team returned %v members, want %v", got, want)
+	}
+}
+
+func TestWithLogger_retry(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var buf bytes.Buffer
+	WithLogger(slog.New(slog.NewTextHandler(&buf, nil)))(client)
+	WithRetryPolicy(DefaultRetryPolicy())(client)
+	fakeSleep(client)
+
+	var calls int
+	mux.HandleFunc("/v1/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			http.Error(w, "Bad Gateway", http.StatusBadGateway)
+		}
+	})
+
+	req, _ := client.NewRequest("GET", "/?access_token=secret", nil)
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "esa: retrying request") || !strings.Contains(out, "attempt=1") {
+		t.Errorf("Logger output %q does not report the retry", out)
+	}
+	if strings.Contains(out, "secret") {
+		t.Errorf("Logger output %q contains the access token", out)
+	}
+}
@@ -0,0 +1,172 @@
+// Package otelesa instruments esa.Client with OpenTelemetry. It records a
+// span for every API call along with metrics of the latency, the errors and
+// the remaining rate limit.
+//
+//	client := esa.NewClient(nil, esa.WithToken(""), esa.WithMiddleware(otelesa.Middleware()))
+//
+// The tracer and the meter are obtained from the global providers unless
+// WithTracerProvider or WithMeterProvider is given.
+package otelesa
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/iwata/go-esa/esa"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name of the tracer and the meter.
+const ScopeName = "github.com/iwata/go-esa/esa/otelesa"
+
+// Attribute keys specific to esa.
+const (
+	RetryCountKey         = attribute.Key("esa.retry_count")
+	RateLimitRemainingKey = attribute.Key("esa.rate_limit.remaining")
+)
+
+// Option configures the instrumentation.
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider sets the TracerProvider creating the spans.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the MeterProvider creating the instruments.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// Middleware returns an esa.Middleware recording a span and metrics per call
+// of esa.Client.Do, which includes all of its retries.
+//
+// Spans are named after the method and the route template of the request
+// such as "GET teams/{team}/posts/{number}". The following metrics are
+// recorded:
+//
+//   - esa.client.request.duration: the latency of API calls in seconds
+//   - esa.client.request.errors: the number of failed API calls by error.type,
+//     see esa.ErrorKind for the values
+//   - esa.client.rate_limit.remaining: the X-RateLimit-Remaining value of
+//     the last response
+func Middleware(opts ...Option) esa.Middleware {
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.tracerProvider == nil {
+		c.tracerProvider = otel.GetTracerProvider()
+	}
+	if c.meterProvider == nil {
+		c.meterProvider = otel.GetMeterProvider()
+	}
+
+	tracer := c.tracerProvider.Tracer(ScopeName, trace.WithSchemaURL(semconv.SchemaURL))
+	meter := c.meterProvider.Meter(ScopeName, metric.WithSchemaURL(semconv.SchemaURL))
+
+	// Errors creating instruments are reported to the global error handler by
+	// the SDK, and the returned instruments are still usable no-ops.
+	duration, err := meter.Float64Histogram("esa.client.request.duration",
+		metric.WithDescription("Duration of esa API calls including retries."),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	errs, err := meter.Int64Counter("esa.client.request.errors",
+		metric.WithDescription("Number of failed esa API calls."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	remaining, err := meter.Int64Gauge("esa.client.rate_limit.remaining",
+		metric.WithDescription("Number of requests remaining in the current rate limit window."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next esa.RoundTripFunc) esa.RoundTripFunc {
+		return func(req *http.Request) (*esa.Response, error) {
+			r := Route(req.URL.Path)
+			attrs := []attribute.KeyValue{
+				semconv.HTTPRequestMethodKey.String(req.Method),
+				semconv.HTTPRoute(r),
+			}
+
+			ctx, span := tracer.Start(req.Context(), req.Method+" "+r,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+				trace.WithAttributes(semconv.ServerAddress(req.URL.Hostname())))
+			defer span.End()
+
+			start := time.Now()
+			resp, err := next(req.WithContext(ctx))
+			elapsed := time.Since(start)
+
+			if resp != nil && resp.Response != nil {
+				attrs = append(attrs, semconv.HTTPResponseStatusCode(resp.StatusCode))
+				span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+				if resp.Attempts > 0 {
+					span.SetAttributes(RetryCountKey.Int(resp.Attempts - 1))
+				}
+				if resp.Rate.Limit > 0 {
+					span.SetAttributes(RateLimitRemainingKey.Int(resp.Rate.Remaining))
+					remaining.Record(ctx, int64(resp.Rate.Remaining))
+				}
+			}
+			if err != nil {
+				kind := semconv.ErrorTypeKey.String(esa.ErrorKind(err))
+				attrs = append(attrs, kind)
+				span.SetAttributes(kind)
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				errs.Add(ctx, 1, metric.WithAttributes(attrs...))
+			}
+			duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(attrs...))
+
+			return resp, err
+		}
+	}
+}
+
+// Route returns the route template of the path of an API request, replacing
+// the team names, numbers and other identifiers with placeholders, e.g.
+// "/v1/teams/docs/posts/1" becomes "teams/{team}/posts/{number}".
+// Using templates keeps the cardinality of span names and metrics low.
+func Route(path string) string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "/"), "v1/")
+	segs := strings.Split(path, "/")
+	for i := 1; i < len(segs); i++ {
+		switch segs[i-1] {
+		case "teams":
+			segs[i] = "{team}"
+		case "posts":
+			segs[i] = "{number}"
+		case "comments":
+			segs[i] = "{id}"
+		case "members":
+			segs[i] = "{screen_name}"
+		case "emojis":
+			segs[i] = "{code}"
+		case "invitations":
+			segs[i] = "{code}"
+		}
+	}
+	return strings.Join(segs, "/")
+}
@@ -0,0 +1,123 @@
+package otelesa
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/iwata/go-esa/esa"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRoute(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/v1/teams", "teams"},
+		{"/v1/teams/docs", "teams/{team}"},
+		{"/v1/teams/docs/invitations", "teams/{team}/invitations"},
+		{"/v1/teams/docs/invitations/mee93383edf699b525e01842d34078e28", "teams/{team}/invitations/{code}"},
+		{"/v1/teams/docs/posts/1/comments", "teams/{team}/posts/{number}/comments"},
+		{"/v1/teams/docs/comments/13/stargazers", "teams/{team}/comments/{id}/stargazers"},
+		{"/v1/teams/posts/posts/1", "teams/{team}/posts/{number}"},
+		{"/v1/teams/docs/members/fukayatsu", "teams/{team}/members/{screen_name}"},
+		{"/v1/teams/docs/emojis/team_emoji", "teams/{team}/emojis/{code}"},
+		{"/v1/user", "user"},
+	}
+
+	for _, tt := range tests {
+		if got := Route(tt.path); got != tt.want {
+			t.Errorf("Route(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/v1/teams/docs/posts/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "75")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "1504574600")
+		fmt.Fprint(w, `{"number": 1}`)
+	})
+	mux.HandleFunc("/v1/teams/docs/posts/2", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": "not_found", "message": "Not found"}`, http.StatusNotFound)
+	})
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	u, _ := url.Parse(server.URL + "/")
+	client := esa.NewClient(nil, esa.WithBaseURL(u),
+		esa.WithMiddleware(Middleware(WithTracerProvider(tp), WithMeterProvider(mp))))
+
+	ctx := context.Background()
+	if _, _, err := client.Posts.Get(ctx, "docs", 1); err != nil {
+		t.Fatalf("Posts.Get returned error: %v", err)
+	}
+	if _, _, err := client.Posts.Get(ctx, "docs", 2); err == nil {
+		t.Fatal("Expected error to be returned.")
+	}
+
+	spans := sr.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("Recorded %d spans, want 2", len(spans))
+	}
+	if got, want := spans[0].Name(), "GET teams/{team}/posts/{number}"; got != want {
+		t.Errorf("Span name is %v, want %v", got, want)
+	}
+	attrs := attribute.NewSet(spans[0].Attributes()...)
+	for k, want := range map[attribute.Key]attribute.Value{
+		"http.request.method":       attribute.StringValue("GET"),
+		"http.route":                attribute.StringValue("teams/{team}/posts/{number}"),
+		"http.response.status_code": attribute.IntValue(200),
+		RetryCountKey:               attribute.IntValue(0),
+		RateLimitRemainingKey:       attribute.IntValue(42),
+	} {
+		if got, _ := attrs.Value(k); got != want {
+			t.Errorf("Span attribute %v is %v, want %v", k, got.Emit(), want.Emit())
+		}
+	}
+	if got := spans[1].Status().Code; got != codes.Error {
+		t.Errorf("Span status is %v, want Error", got)
+	}
+	attrs = attribute.NewSet(spans[1].Attributes()...)
+	if got, _ := attrs.Value("error.type"); got.AsString() != "not_found" {
+		t.Errorf("Span error.type is %v, want not_found", got.Emit())
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+
+	if h, ok := metrics["esa.client.request.duration"].(metricdata.Histogram[float64]); !ok || len(h.DataPoints) != 2 {
+		t.Errorf("esa.client.request.duration is %+v, want 2 data points", metrics["esa.client.request.duration"])
+	}
+	if s, ok := metrics["esa.client.request.errors"].(metricdata.Sum[int64]); !ok || len(s.DataPoints) != 1 || s.DataPoints[0].Value != 1 {
+		t.Errorf("esa.client.request.errors is %+v, want 1 error", metrics["esa.client.request.errors"])
+	}
+	if g, ok := metrics["esa.client.rate_limit.remaining"].(metricdata.Gauge[int64]); !ok || len(g.DataPoints) != 1 || g.DataPoints[0].Value != 42 {
+		t.Errorf("esa.client.rate_limit.remaining is %+v, want 42", metrics["esa.client.rate_limit.remaining"])
+	}
+}
@@ -0,0 +1,130 @@
+package esa
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// RateLimitPolicy determines how Client.Do behaves when the rate limit of
+// the client is exhausted.
+type RateLimitPolicy int
+
+const (
+	// RateLimitFailFast makes Client.Do return *RateLimitError without
+	// making a network call while the rate limit is known to be exceeded.
+	// This is the default.
+	RateLimitFailFast RateLimitPolicy = iota
+
+	// RateLimitWait makes Client.Do block until the rate limit is reset,
+	// and retry once a request rejected with 429 Too Many Requests.
+	RateLimitWait
+
+	// RateLimitThrottle behaves like RateLimitWait, and in addition spaces
+	// requests out so that the remaining requests last until the rate
+	// limit is reset.
+	RateLimitThrottle
+)
+
+// Rate returns the rate limit of the client as determined by the most recent
+// API call. It is safe to call concurrently with requests.
+func (c *Client) Rate() Rate {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	return c.rateLimit
+}
+
+// setRate records the rate limit parsed from a response, notifying
+// c.onRateChange if it changed.
+func (c *Client) setRate(rate Rate) {
+	c.rateMu.Lock()
+	prev := c.rateLimit
+	c.rateLimit = rate
+	c.rateMu.Unlock()
+
+	if c.onRateChange != nil && (rate.Limit != prev.Limit || rate.Remaining != prev.Remaining ||
+		!rate.Reset.Time.Equal(prev.Reset.Time)) {
+		c.onRateChange(rate)
+	}
+}
+
+// waitRateLimit blocks according to c.RateLimitPolicy and the most recent
+// rate limit. It reports whether it waited for the rate limit to be reset.
+// If ctx is done while waiting, ctx.Err() is returned.
+func (c *Client) waitRateLimit(ctx context.Context) (bool, error) {
+	if c.RateLimitPolicy == RateLimitFailFast {
+		return false, nil
+	}
+
+	c.rateMu.Lock()
+	rate := c.rateLimit
+	now := time.Now()
+	var d time.Duration
+	reset := false
+	if !rate.Reset.Time.IsZero() && now.Before(rate.Reset.Time) {
+		untilReset := rate.Reset.Time.Sub(now)
+		switch {
+		case rate.Remaining == 0:
+			d, reset = untilReset, true
+		case c.RateLimitPolicy == RateLimitThrottle:
+			// Reserve the next free slot, so that concurrent callers are
+			// spread across the window as well.
+			next := c.throttleNext
+			if next.Before(now) {
+				next = now
+			}
+			d = next.Sub(now)
+			c.throttleNext = next.Add(untilReset / time.Duration(rate.Remaining))
+		}
+	}
+	c.rateMu.Unlock()
+
+	if d <= 0 {
+		return false, nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && reset && deadline.Before(now.Add(d)) {
+		// No point in waiting for a reset that happens after ctx expires.
+		return false, nil
+	}
+	if c.logger != nil {
+		c.logger.LogAttrs(ctx, slog.LevelInfo, "esa: waiting for rate limit",
+			slog.Duration("wait", d),
+			slog.Int("remaining", rate.Remaining),
+			slog.Time("reset", rate.Reset.Time))
+	}
+	if err := c.sleep(ctx, d); err != nil {
+		return false, err
+	}
+	return reset, nil
+}
+
+// sleepContext pauses the current goroutine for at least the duration d,
+// or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// rewindBody resets the body of req so that req can be sent again.
+func rewindBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	if req.GetBody == nil {
+		return errors.New("esa: request body cannot be rewound")
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
@@ -0,0 +1,290 @@
+package esa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSleep replaces the sleep function of client, recording the requested
+// durations instead of actually sleeping.
+func fakeSleep(c *Client) *[]time.Duration {
+	var (
+		mu    sync.Mutex
+		slept []time.Duration
+	)
+	c.sleep = func(ctx context.Context, d time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		slept = append(slept, d)
+		return ctx.Err()
+	}
+	return &slept
+}
+
+func rateLimitExceeded(reset time.Time) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "75")
+		w.Header().Set(headerRateRemaining, "0")
+		w.Header().Set(headerRateReset, fmt.Sprint(reset.Unix()))
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprintln(w, `{"error": "too_many_requests", "message": "Too Many Requests"}`)
+	}
+}
+
+func TestDo_rateLimitWait(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RateLimitPolicy = RateLimitWait
+	slept := fakeSleep(client)
+	reset := time.Now().Add(time.Minute).Round(time.Second)
+
+	mux.HandleFunc("/v1/first", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "75")
+		w.Header().Set(headerRateRemaining, "0")
+		w.Header().Set(headerRateReset, fmt.Sprint(reset.Unix()))
+	})
+	madeNetworkCall := false
+	mux.HandleFunc("/v1/second", func(w http.ResponseWriter, r *http.Request) {
+		madeNetworkCall = true
+	})
+
+	req, _ := client.NewRequest("GET", "first", nil)
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+	if len(*slept) != 0 {
+		t.Errorf("Do slept %v before the first request", *slept)
+	}
+
+	req, _ = client.NewRequest("GET", "second", nil)
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+	if !madeNetworkCall {
+		t.Error("Network call was not made after waiting for the rate limit reset.")
+	}
+	if len(*slept) != 1 {
+		t.Fatalf("Do slept %d times, want 1", len(*slept))
+	}
+	if d := (*slept)[0]; d <= 0 || d > time.Minute+time.Second {
+		t.Errorf("Do slept %v, want about a minute", d)
+	}
+}
+
+// Ensure a request does not wait beyond the deadline of its context.
+func TestDo_rateLimitWait_deadline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RateLimitPolicy = RateLimitWait
+	slept := fakeSleep(client)
+	reset := time.Now().Add(time.Minute).Round(time.Second)
+	mux.HandleFunc("/v1/first", rateLimitExceeded(reset))
+	madeNetworkCall := false
+	mux.HandleFunc("/v1/second", func(w http.ResponseWriter, r *http.Request) {
+		madeNetworkCall = true
+	})
+
+	// The first 429 is retried once after the reset.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	req, _ := client.NewRequest("GET", "first", nil)
+	_, err := client.Do(ctx, req, nil)
+	if _, ok := err.(*RateLimitError); !ok {
+		t.Fatalf("Expected a *RateLimitError error; got %#v.", err)
+	}
+
+	req, _ = client.NewRequest("GET", "second", nil)
+	_, err = client.Do(ctx, req, nil)
+	if _, ok := err.(*RateLimitError); !ok {
+		t.Fatalf("Expected a *RateLimitError error; got %#v.", err)
+	}
+	if madeNetworkCall {
+		t.Error("Network call was made, even though rate limit is known to still be exceeded.")
+	}
+	if len(*slept) != 0 {
+		t.Errorf("Do slept %v, even though ctx expires before the reset", *slept)
+	}
+}
+
+func TestDo_rateLimitWait_canceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RateLimitPolicy = RateLimitWait
+	client.rateLimit = Rate{Limit: 75, Remaining: 0, Reset: Timestamp{time.Now().Add(time.Hour)}}
+	mux.HandleFunc("/v1/", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Network call was made, even though ctx was canceled while waiting.")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	req, _ := client.NewRequest("GET", "/", nil)
+	if _, err := client.Do(ctx, req, nil); err != context.Canceled {
+		t.Errorf("Do returned %v, want %v", err, context.Canceled)
+	}
+}
+
+// Ensure a request rejected with 429 is sent again, body included, after the reset.
+func TestDo_rateLimitWait_retryTooManyRequests(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RateLimitPolicy = RateLimitWait
+	slept := fakeSleep(client)
+	reset := time.Now().Add(time.Minute).Round(time.Second)
+
+	var calls int
+	mux.HandleFunc("/v1/teams/hoge/posts", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		v := new(postRequest)
+		json.NewDecoder(r.Body).Decode(v)
+		if want := (&PostRequest{Name: "hi!"}); !reflect.DeepEqual(v.Post, want) {
+			t.Errorf("Request body = %+v, want %+v", v.Post, want)
+		}
+		if calls == 1 {
+			rateLimitExceeded(reset)(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"number": 1}`)
+	})
+
+	post, _, err := client.Posts.Create(context.Background(), "hoge", &PostRequest{Name: "hi!"})
+	if err != nil {
+		t.Fatalf("Posts.Create returned error: %v", err)
+	}
+	if post.Number != 1 {
+		t.Errorf("Posts.Create returned %+v", post)
+	}
+	if calls != 2 {
+		t.Errorf("Request was sent %d times, want 2", calls)
+	}
+	if len(*slept) != 1 {
+		t.Errorf("Do slept %d times, want 1", len(*slept))
+	}
+}
+
+func TestDo_rateLimitThrottle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RateLimitPolicy = RateLimitThrottle
+	slept := fakeSleep(client)
+	reset := time.Now().Add(100 * time.Second).Round(time.Second)
+
+	mux.HandleFunc("/v1/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "75")
+		w.Header().Set(headerRateRemaining, "10")
+		w.Header().Set(headerRateReset, fmt.Sprint(reset.Unix()))
+	})
+
+	for i := 0; i < 3; i++ {
+		req, _ := client.NewRequest("GET", "/", nil)
+		if _, err := client.Do(context.Background(), req, nil); err != nil {
+			t.Fatalf("Do returned unexpected error: %v", err)
+		}
+	}
+
+	// The first request knows nothing about the rate limit, the second one
+	// is free to go, and the third one waits for its share of the window.
+	if len(*slept) != 1 {
+		t.Fatalf("Do slept %d times, want 1", len(*slept))
+	}
+	if d := (*slept)[0]; d < 9*time.Second || d > 11*time.Second {
+		t.Errorf("Do slept %v, want about 10s", d)
+	}
+}
+
+func TestDo_rateLimitFailFast(t *testing.T) {
+	setup()
+	defer teardown()
+
+	slept := fakeSleep(client)
+	mux.HandleFunc("/v1/", rateLimitExceeded(time.Now().Add(time.Minute)))
+
+	req, _ := client.NewRequest("GET", "/", nil)
+	_, err := client.Do(context.Background(), req, nil)
+	if _, ok := err.(*RateLimitError); !ok {
+		t.Fatalf("Expected a *RateLimitError error; got %#v.", err)
+	}
+	if len(*slept) != 0 {
+		t.Errorf("Do slept %v with RateLimitFailFast", *slept)
+	}
+}
+
+func TestClient_Rate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "75")
+		w.Header().Set(headerRateRemaining, "74")
+		w.Header().Set(headerRateReset, "1504574600")
+		fmt.Fprint(w, `{"teams": []}`)
+	})
+
+	if got := client.Rate(); got.Limit != 0 {
+		t.Errorf("Rate before any request is %v, want zero", got)
+	}
+	if _, _, err := client.Teams.List(context.Background(), nil); err != nil {
+		t.Fatalf("Teams.List returned error: %v", err)
+	}
+
+	want := Rate{Limit: 75, Remaining: 74, Reset: Timestamp{time.Unix(1504574600, 0)}}
+	if got := client.Rate(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Rate is %v, want %v", got, want)
+	}
+}
+
+func TestWithRateChangeFunc(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var remaining = 75
+	mux.HandleFunc("/v1/teams", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "75")
+		w.Header().Set(headerRateRemaining, fmt.Sprint(remaining))
+		w.Header().Set(headerRateReset, "1504574600")
+		fmt.Fprint(w, `{"teams": []}`)
+	})
+
+	var changes []int
+	WithRateChangeFunc(func(r Rate) {
+		changes = append(changes, r.Remaining)
+	})(client)
+
+	for _, r := range []int{74, 74, 73} {
+		remaining = r
+		if _, _, err := client.Teams.List(context.Background(), nil); err != nil {
+			t.Fatalf("Teams.List returned error: %v", err)
+		}
+	}
+	if want := []int{74, 73}; !reflect.DeepEqual(changes, want) {
+		t.Errorf("Rate changes are %v, want %v", changes, want)
+	}
+}
+
+func TestRate_Err(t *testing.T) {
+	res := &http.Response{Header: make(http.Header)}
+	res.Header.Set(headerRateRemaining, "many")
+
+	if err := parseRate(res).Err(); err == nil {
+		t.Error("Expected error to be returned.")
+	}
+
+	res.Header.Set(headerRateRemaining, "74")
+	if err := parseRate(res).Err(); err != nil {
+		t.Errorf("Rate.Err returned %v, want nil", err)
+	}
+}
@@ -0,0 +1,97 @@
+package esa
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy configures how Client.Do retries requests that failed
+// transiently, such as with a connection reset or a 502 Bad Gateway.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first
+	// one. Values less than 2 disable retrying.
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry. It doubles with
+	// every further retry up to MaxBackoff, and a random jitter of up to
+	// half the delay is subtracted from it.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// RetryableStatus lists the HTTP status codes worth retrying.
+	RetryableStatus []int
+
+	// RetryNonIdempotent allows retrying POST and PATCH requests, which may
+	// be applied twice by esa if only the response got lost.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy making up to 3 attempts of
+// idempotent requests failing with network errors or 502, 503 and 504.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+		RetryableStatus: []int{
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// shouldRetry reports whether req should be sent again after the given
+// attempt ended with resp and err.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, req *http.Request, resp *Response, err error, attempt int) bool {
+	if p == nil || err == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return false
+	}
+	if ctx.Err() != nil {
+		return false
+	}
+	if _, ok := err.(*RateLimitError); ok {
+		// Handled by RateLimitPolicy.
+		return false
+	}
+	if resp == nil || resp.Response == nil {
+		// The request failed without any response, e.g. connection reset.
+		return true
+	}
+	for _, code := range p.RetryableStatus {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay before the retry following the given attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if half := int64(d / 2); half > 0 {
+		d -= time.Duration(rand.Int63n(half))
+	}
+	return d
+}
+
+// isIdempotent reports whether requests of the HTTP method may be safely
+// sent more than once.
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "TRACE", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
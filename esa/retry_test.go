@@ -0,0 +1,220 @@
+package esa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDo_retry(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RetryPolicy = DefaultRetryPolicy()
+	slept := fakeSleep(client)
+
+	var calls int
+	mux.HandleFunc("/v1/teams/hoge", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			http.Error(w, "Bad Gateway", http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"name": "hoge"}`)
+	})
+
+	team, resp, err := client.Teams.Get(context.Background(), "hoge")
+	if err != nil {
+		t.Fatalf("Teams.Get returned error: %v", err)
+	}
+	if want := (&Team{Name: "hoge"}); !reflect.DeepEqual(team, want) {
+		t.Errorf("Teams.Get returned %+v, want %+v", team, want)
+	}
+	if got, want := resp.Attempts, 2; got != want {
+		t.Errorf("Response.Attempts = %v, want %v", got, want)
+	}
+	if len(*slept) != 1 {
+		t.Errorf("Do slept %d times, want 1", len(*slept))
+	}
+}
+
+func TestDo_retry_exhausted(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RetryPolicy = DefaultRetryPolicy()
+	slept := fakeSleep(client)
+
+	var calls int
+	mux.HandleFunc("/v1/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+	})
+
+	req, _ := client.NewRequest("DELETE", "/", nil)
+	resp, err := client.Do(context.Background(), req, nil)
+	if _, ok := err.(*ErrorResponse); !ok {
+		t.Fatalf("Expected a *ErrorResponse error; got %#v.", err)
+	}
+	if calls != 3 {
+		t.Errorf("Request was sent %d times, want 3", calls)
+	}
+	if got, want := resp.Attempts, 3; got != want {
+		t.Errorf("Response.Attempts = %v, want %v", got, want)
+	}
+	if len(*slept) != 2 {
+		t.Errorf("Do slept %d times, want 2", len(*slept))
+	}
+}
+
+func TestDo_retry_notRetryableStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RetryPolicy = DefaultRetryPolicy()
+	fakeSleep(client)
+
+	var calls int
+	mux.HandleFunc("/v1/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	req, _ := client.NewRequest("GET", "/", nil)
+	resp, _ := client.Do(context.Background(), req, nil)
+	if calls != 1 {
+		t.Errorf("Request was sent %d times, want 1", calls)
+	}
+	if got, want := resp.Attempts, 1; got != want {
+		t.Errorf("Response.Attempts = %v, want %v", got, want)
+	}
+}
+
+// Ensure a non-idempotent request is not retried unless explicitly allowed.
+func TestDo_retry_nonIdempotent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RetryPolicy = DefaultRetryPolicy()
+	fakeSleep(client)
+
+	var calls int
+	mux.HandleFunc("/v1/teams/hoge/posts", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		v := new(postRequest)
+		json.NewDecoder(r.Body).Decode(v)
+		if want := (&PostRequest{Name: "hi!"}); !reflect.DeepEqual(v.Post, want) {
+			t.Errorf("Request body = %+v, want %+v", v.Post, want)
+		}
+		if calls == 1 {
+			http.Error(w, "Bad Gateway", http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"number": 1}`)
+	})
+
+	_, _, err := client.Posts.Create(context.Background(), "hoge", &PostRequest{Name: "hi!"})
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+	if calls != 1 {
+		t.Errorf("Request was sent %d times, want 1", calls)
+	}
+
+	calls = 0
+	client.RetryPolicy.RetryNonIdempotent = true
+	_, resp, err := client.Posts.Create(context.Background(), "hoge", &PostRequest{Name: "hi!"})
+	if err != nil {
+		t.Errorf("Posts.Create returned error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Request was sent %d times, want 2", calls)
+	}
+	if got, want := resp.Attempts, 2; got != want {
+		t.Errorf("Response.Attempts = %v, want %v", got, want)
+	}
+}
+
+func TestDo_retry_networkError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RetryPolicy = DefaultRetryPolicy()
+	fakeSleep(client)
+
+	var calls int
+	mux.HandleFunc("/v1/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatalf("Hijack returned error: %v", err)
+			}
+			conn.Close()
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	})
+
+	req, _ := client.NewRequest("GET", "/", nil)
+	resp, err := client.Do(context.Background(), req, nil)
+	if err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+	if got, want := resp.Attempts, 2; got != want {
+		t.Errorf("Response.Attempts = %v, want %v", got, want)
+	}
+}
+
+func TestDo_retry_canceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RetryPolicy = DefaultRetryPolicy()
+	ctx, cancel := context.WithCancel(context.Background())
+	client.sleep = func(context.Context, time.Duration) error {
+		cancel()
+		return ctx.Err()
+	}
+
+	var calls int
+	mux.HandleFunc("/v1/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, "Bad Gateway", http.StatusBadGateway)
+	})
+
+	req, _ := client.NewRequest("GET", "/", nil)
+	if _, err := client.Do(ctx, req, nil); err != context.Canceled {
+		t.Errorf("Do returned %v, want %v", err, context.Canceled)
+	}
+	if calls != 1 {
+		t.Errorf("Request was sent %d times, want 1", calls)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 500 * time.Millisecond, time.Second},
+		{2, time.Second, 2 * time.Second},
+		{3, 2 * time.Second, 4 * time.Second},
+		{4, 2500 * time.Millisecond, 5 * time.Second},
+		{10, 2500 * time.Millisecond, 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 10; i++ {
+			if d := p.backoff(tt.attempt); d < tt.min || d > tt.max {
+				t.Errorf("backoff(%d) = %v, want between %v and %v", tt.attempt, d, tt.min, tt.max)
+			}
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package esa
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// Priority is the priority of a request under a SchedulePolicy.
+type Priority int
+
+const (
+	// PriorityInteractive is for requests a user is waiting for.
+	// This is the default.
+	PriorityInteractive Priority = iota
+
+	// PriorityBackground is for batch jobs, which give way to interactive
+	// requests and leave part of the rate limit to them.
+	PriorityBackground
+)
+
+type priorityKey struct{}
+
+// WithPriority returns a copy of ctx making the requests sent with it
+// scheduled with the priority p.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// priorityFromContext returns the priority carried by ctx.
+func priorityFromContext(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+	return PriorityInteractive
+}
+
+// SchedulePolicy configures how a Client schedules requests sent concurrently
+// from multiple goroutines.
+type SchedulePolicy struct {
+	// MaxInFlight is the maximum number of requests sent at the same time.
+	// A request is in flight until its response body is closed, which Do
+	// does once the body is decoded. Zero means no limit.
+	MaxInFlight int
+
+	// BackgroundReserve is the number of remaining requests of the rate
+	// limit that background requests leave to interactive ones. Background
+	// requests wait for the rate limit to be reset once less remain.
+	BackgroundReserve int
+}
+
+// DefaultSchedulePolicy returns a SchedulePolicy sending up to 4 requests at
+// the same time and reserving 15 requests, a fifth of the rate limit of esa,
+// to interactive ones.
+func DefaultSchedulePolicy() *SchedulePolicy {
+	return &SchedulePolicy{
+		MaxInFlight:       4,
+		BackgroundReserve: 15,
+	}
+}
+
+// scheduler admits the requests of a client according to a SchedulePolicy.
+// Interactive requests are admitted before waiting background ones, and the
+// requests in flight are never more than the remaining rate limit, so that
+// concurrent goroutines share it instead of all hitting 429.
+type scheduler struct {
+	policy SchedulePolicy
+	rate   func() Rate
+
+	mu                 sync.Mutex
+	inFlight           int
+	waitingInteractive int
+	changed            chan struct{} // closed when waiting requests may be admitted
+}
+
+func newScheduler(p *SchedulePolicy, rate func() Rate) *scheduler {
+	return &scheduler{policy: *p, rate: rate, changed: make(chan struct{})}
+}
+
+// acquire blocks until a request with the priority carried by ctx may be
+// sent. The caller must call release once the response body is closed.
+func (s *scheduler) acquire(ctx context.Context) error {
+	p := priorityFromContext(ctx)
+
+	s.mu.Lock()
+	if p == PriorityInteractive {
+		s.waitingInteractive++
+		defer func() {
+			s.mu.Lock()
+			s.waitingInteractive--
+			s.notify() // background requests may go now
+			s.mu.Unlock()
+		}()
+	}
+	for {
+		ok, reset := s.admit(p)
+		if ok {
+			s.inFlight++
+			s.mu.Unlock()
+			return nil
+		}
+		changed := s.changed
+		s.mu.Unlock()
+
+		var (
+			t       *time.Timer
+			timeout <-chan time.Time
+		)
+		if !reset.IsZero() {
+			// The budget is exhausted until reset, unless a response in
+			// flight tells otherwise.
+			t = time.NewTimer(time.Until(reset))
+			timeout = t.C
+		}
+		var err error
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+		case <-changed:
+		case <-timeout:
+		}
+		if t != nil {
+			t.Stop()
+		}
+		if err != nil {
+			return err
+		}
+		s.mu.Lock()
+	}
+}
+
+// admit reports whether a request with the priority p may be sent now. If
+// not because of the rate limit, it also returns the time of its reset.
+// s.mu must be held.
+func (s *scheduler) admit(p Priority) (bool, time.Time) {
+	if s.policy.MaxInFlight > 0 && s.inFlight >= s.policy.MaxInFlight {
+		return false, time.Time{}
+	}
+	// waitingInteractive includes the caller itself if it is interactive.
+	if p == PriorityBackground && s.waitingInteractive > 0 {
+		return false, time.Time{}
+	}
+
+	rate := s.rate()
+	if rate.Reset.Time.IsZero() || !time.Now().Before(rate.Reset.Time) {
+		// The rate limit is unknown or has been reset.
+		return true, time.Time{}
+	}
+	budget := rate.Remaining - s.inFlight
+	if p == PriorityBackground {
+		budget -= s.policy.BackgroundReserve
+	}
+	if budget > 0 {
+		return true, time.Time{}
+	}
+	if p == PriorityInteractive && s.inFlight == 0 {
+		// Let the request through for RateLimitPolicy to handle it.
+		return true, time.Time{}
+	}
+	return false, rate.Reset.Time
+}
+
+// release marks a request admitted by acquire as done.
+func (s *scheduler) release() {
+	s.mu.Lock()
+	s.inFlight--
+	s.notify()
+	s.mu.Unlock()
+}
+
+// releaseBody is a response body calling release once it is closed.
+type releaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// notify wakes up the requests waiting in acquire. s.mu must be held.
+func (s *scheduler) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
@@ -0,0 +1,195 @@
+package esa
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitFor polls cond until it holds, failing the test after a second.
+func waitFor(t *testing.T, s *scheduler, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		s.mu.Lock()
+		ok := cond()
+		s.mu.Unlock()
+		if ok {
+			return
+		}
+	}
+	t.Fatal("Timed out waiting for the scheduler")
+}
+
+func TestWithPriority(t *testing.T) {
+	ctx := context.Background()
+	if got, want := priorityFromContext(ctx), PriorityInteractive; got != want {
+		t.Errorf("Default priority is %v, want %v", got, want)
+	}
+	ctx = WithPriority(ctx, PriorityBackground)
+	if got, want := priorityFromContext(ctx), PriorityBackground; got != want {
+		t.Errorf("Priority is %v, want %v", got, want)
+	}
+}
+
+func TestClient_schedule_maxInFlight(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var (
+		mu       sync.Mutex
+		inFlight int
+		max      int
+	)
+	mux.HandleFunc("/v1/teams", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > max {
+			max = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		fmt.Fprint(w, `{"teams": []}`)
+	})
+
+	WithSchedulePolicy(&SchedulePolicy{MaxInFlight: 2})(client)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.Teams.List(context.Background(), nil); err != nil {
+				t.Errorf("Teams.List returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if max > 2 {
+		t.Errorf("%d requests were in flight, want at most 2", max)
+	}
+}
+
+func TestClient_schedule_maxInFlightBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	var sent bool
+	mux.HandleFunc("/v1/teams/a", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": `)
+		w.(http.Flusher).Flush()
+		close(started)
+		<-unblock
+		fmt.Fprint(w, `"a"}`)
+	})
+	mux.HandleFunc("/v1/teams/b", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-unblock:
+		default:
+			sent = true
+		}
+		fmt.Fprint(w, `{"name": "b"}`)
+	})
+
+	WithSchedulePolicy(&SchedulePolicy{MaxInFlight: 1})(client)
+
+	var wg sync.WaitGroup
+	for _, team := range []string{"a", "b"} {
+		if team == "b" {
+			<-started
+			// Let the headers of a arrive before b is scheduled.
+			time.Sleep(10 * time.Millisecond)
+		}
+		wg.Add(1)
+		go func(team string) {
+			defer wg.Done()
+			if _, _, err := client.Teams.Get(context.Background(), team); err != nil {
+				t.Errorf("Teams.Get returned error: %v", err)
+			}
+		}(team)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(unblock)
+	wg.Wait()
+
+	if sent {
+		t.Error("Request was sent while the body of another was downloaded")
+	}
+}
+
+func TestScheduler_priority(t *testing.T) {
+	s := newScheduler(&SchedulePolicy{MaxInFlight: 1}, func() Rate { return Rate{} })
+	ctx := context.Background()
+	if err := s.acquire(ctx); err != nil {
+		t.Fatalf("acquire returned error: %v", err)
+	}
+
+	admitted := make(chan Priority, 2)
+	for _, p := range []Priority{PriorityBackground, PriorityInteractive} {
+		go func(p Priority) {
+			if err := s.acquire(WithPriority(ctx, p)); err != nil {
+				t.Errorf("acquire returned error: %v", err)
+			}
+			admitted <- p
+			s.release()
+		}(p)
+		if p == PriorityBackground {
+			// Let the background request wait first.
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	waitFor(t, s, func() bool { return s.waitingInteractive == 1 })
+
+	s.release()
+	if got, want := <-admitted, PriorityInteractive; got != want {
+		t.Errorf("Admitted %v first, want %v", got, want)
+	}
+	if got, want := <-admitted, PriorityBackground; got != want {
+		t.Errorf("Admitted %v second, want %v", got, want)
+	}
+}
+
+func TestScheduler_rateBudget(t *testing.T) {
+	rate := Rate{Limit: 75, Remaining: 2, Reset: Timestamp{time.Now().Add(time.Hour)}}
+	s := newScheduler(&SchedulePolicy{BackgroundReserve: 1}, func() Rate { return rate })
+	background := WithPriority(context.Background(), PriorityBackground)
+
+	if err := s.acquire(background); err != nil {
+		t.Fatalf("acquire returned error: %v", err)
+	}
+
+	// The remaining request is reserved for interactive requests.
+	ctx, cancel := context.WithTimeout(background, 10*time.Millisecond)
+	defer cancel()
+	if err := s.acquire(ctx); err != context.DeadlineExceeded {
+		t.Errorf("acquire of a background request returned %v, want %v", err, context.DeadlineExceeded)
+	}
+	if err := s.acquire(context.Background()); err != nil {
+		t.Errorf("acquire of an interactive request returned error: %v", err)
+	}
+
+	// No request remains until the reset.
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.acquire(ctx); err != context.DeadlineExceeded {
+		t.Errorf("acquire of an interactive request returned %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// A reset rate limit lets the requests through.
+	s.mu.Lock()
+	rate.Reset = Timestamp{time.Now().Add(-time.Second)}
+	s.mu.Unlock()
+	if err := s.acquire(background); err != nil {
+		t.Errorf("acquire after the reset returned error: %v", err)
+	}
+}
@@ -96,6 +96,12 @@ func TestString(t *testing.T) {
 			MaxPerPage: 100,
 		}, `esa.InvitationList{Invitations:[esa.Invitation{Email:"foo@example.com", Code:"mee93383edf699b525e01842d34078e28", ExpiresAt:esa.Timestamp{2017-09-06 10:00:00 +0000 UTC}, URL:"https://docs.esa.io/team/invitations/mee93383edf699b525e01842d34078e28/join"}], PrevPage:0, NextPage:0, TotalCount:2, Page:1, PerPage:20, MaxPerPage:100}`},
 		{InvitationMember{Member: &InvitationEmails{[]string{"foo@example.com"}}}, `esa.InvitationMember{Member:esa.InvitationEmails{Emails:["foo@example.com"]}}`},
+		{Author{Name: "Atsuo Fukaya", ScreenName: "fukayatsu"}, `esa.Author{Myself:false, Name:"Atsuo Fukaya", ScreenName:"fukayatsu", Icon:""}`},
+		{PostRequest{Name: "hi!", WIP: Bool(false)}, `esa.PostRequest{Name:"hi!", BodyMD:"", Category:"", WIP:false, Message:""}`},
+		{StarRequest{Body: "foo"}, `esa.StarRequest{Body:"foo"}`},
+		{BatchMoveResult{Count: 3, From: "/foo/", To: "/bar/"}, `esa.BatchMoveResult{Count:3, From:"/foo/", To:"/bar/"}`},
+		{Tag{Name: "api", PostsCount: 3}, `esa.Tag{Name:"api", PostsCount:3}`},
+		{Emoji{Code: "+1", Aliases: []string{"+1", "thumbsup"}}, `esa.Emoji{Code:"+1", Aliases:["+1" "thumbsup"], Category:"", URL:""}`},
 	}
 
 	for i, tt := range tests {
@@ -0,0 +1,75 @@
+package esa
+
+import (
+	"net/http"
+	"strings"
+)
+
+// EnvAccessToken is the environment variable WithToken reads the access
+// token from when no token is given.
+const EnvAccessToken = "ESA_ACCESS_TOKEN"
+
+// TokenTransport is an http.RoundTripper that authenticates requests with
+// an esa access token in the Authorization header.
+//
+// ref. https://docs.esa.io/posts/102#3-0-0
+type TokenTransport struct {
+	// Token is the personal access token or the OAuth access token.
+	Token string
+
+	// Base is the underlying RoundTripper making the requests.
+	// If nil, http.DefaultTransport is used.
+	Base http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *TokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrip must not modify the given request.
+	r := req.Clone(req.Context())
+	r.Header.Set("Authorization", "Bearer "+t.Token)
+
+	resp, err := t.base().RoundTrip(r)
+	if err != nil {
+		return nil, redactToken(err, t.Token)
+	}
+	return resp, nil
+}
+
+// Client returns an *http.Client that authenticates requests with t.
+func (t *TokenTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+func (t *TokenTransport) String() string {
+	return `esa.TokenTransport{Token:"REDACTED"}`
+}
+
+func (t *TokenTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// tokenRedactedError hides an access token from the message of err.
+type tokenRedactedError struct {
+	err   error
+	token string
+}
+
+func (e *tokenRedactedError) Error() string {
+	return strings.Replace(e.err.Error(), e.token, "REDACTED", -1)
+}
+
+func (e *tokenRedactedError) Unwrap() error {
+	return e.err
+}
+
+// redactToken redacts token from the message of err which may be exposed
+// to the user.
+func redactToken(err error, token string) error {
+	if token == "" || !strings.Contains(err.Error(), token) {
+		return err
+	}
+	return &tokenRedactedError{err: err, token: token}
+}
@@ -0,0 +1,111 @@
+package esa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTokenTransport(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "Bearer token"; got != want {
+			t.Errorf("Authorization is %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"teams": []}`)
+	})
+
+	client.client = (&TokenTransport{Token: "token"}).Client()
+	req, _ := client.NewRequest("GET", "teams", nil)
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+	if got := req.Header.Get("Authorization"); got != "" {
+		t.Errorf("TokenTransport modified the original request: Authorization is %v", got)
+	}
+}
+
+func TestTokenTransport_redactsErrors(t *testing.T) {
+	tr := &TokenTransport{
+		Token: "secret",
+		Base: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("proxy rejected " + req.Header.Get("Authorization"))
+		}),
+	}
+
+	req, _ := http.NewRequest("GET", "https://api.esa.io/v1/teams", nil)
+	_, err := tr.RoundTrip(req)
+	if err == nil {
+		t.Fatal("Expected error to be returned.")
+	}
+	if got, want := err.Error(), "proxy rejected Bearer REDACTED"; got != want {
+		t.Errorf("RoundTrip returned error %q, want %q", got, want)
+	}
+	if errors.Unwrap(err) == nil {
+		t.Error("Redacted error does not wrap the original error")
+	}
+
+	c := NewClient(tr.Client())
+	req, _ = c.NewRequest("GET", "teams", nil)
+	_, err = c.Do(context.Background(), req, nil)
+	if err == nil || strings.Contains(err.Error(), "secret") {
+		t.Errorf("Do returned error %v, want token redacted", err)
+	}
+}
+
+func TestTokenTransport_String(t *testing.T) {
+	tr := &TokenTransport{Token: "secret"}
+	if s := fmt.Sprint(tr); strings.Contains(s, "secret") {
+		t.Errorf("TokenTransport.String() = %q contains the token", s)
+	}
+}
+
+func TestWithToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/teams", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "Bearer token"; got != want {
+			t.Errorf("Authorization is %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"teams": []}`)
+	})
+
+	WithToken("token")(client)
+	if _, _, err := client.Teams.List(context.Background(), nil); err != nil {
+		t.Fatalf("Teams.List returned error: %v", err)
+	}
+	if http.DefaultClient.Transport != nil {
+		t.Errorf("WithToken modified http.DefaultClient")
+	}
+}
+
+func TestWithToken_env(t *testing.T) {
+	t.Setenv(EnvAccessToken, "env-token")
+
+	c := NewClient(nil, WithToken(""))
+	tr, ok := c.client.Transport.(*TokenTransport)
+	if !ok {
+		t.Fatalf("Transport is %v, want *TokenTransport", c.client.Transport)
+	}
+	if got, want := tr.Token, "env-token"; got != want {
+		t.Errorf("Token is %v, want %v", got, want)
+	}
+
+	t.Setenv(EnvAccessToken, "")
+	c = NewClient(nil, WithToken(""))
+	if _, ok := c.client.Transport.(*TokenTransport); ok {
+		t.Error("WithToken set a TokenTransport without any token")
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/iwata/go-esa
+
+go 1.21